@@ -0,0 +1,64 @@
+package sbdb
+
+import (
+	"strings"
+	"time"
+)
+
+// CloseApproach is a close approach of a small body to a planet or other
+// major body.
+type CloseApproach struct {
+	Designation string    // Primary designation of the small body, if known
+	FullName    string    // Full name of the small body, if requested
+	OrbitID     string    // Orbit solution used to compute the approach
+	Body        string    // Body approached, e.g. "Earth"
+	Time        time.Time // Time of closest approach (TDB)
+	JD          *float64  // Time of closest approach (JD, TDB)
+	Dist        *float64  // Nominal approach distance (au)
+	DistMin     *float64  // Minimum 3-sigma approach distance (au)
+	DistMax     *float64  // Maximum 3-sigma approach distance (au)
+	VRel        *float64  // Velocity relative to the approached body (km/s)
+	VInf        *float64  // Velocity relative to a massless approached body (km/s)
+	TSigma      string    // 3-sigma uncertainty in the approach time, e.g. "00:02"
+
+	H             *float64 // Absolute magnitude of the small body
+	Diameter      *float64 // Diameter of the small body (km), if requested and known
+	DiameterSigma *float64 // 1-sigma uncertainty of Diameter (km)
+}
+
+// closeApproach converts a record keyed by the API's close-approach field
+// names, as used by both the object API and the CAD API.
+func (r Record) closeApproach() CloseApproach {
+	ca := CloseApproach{
+		Time:        deref(r.getTime("cd")),
+		Designation: deref(r.getString("des")),
+		FullName:    strings.TrimSpace(deref(r.getString("fullname"))),
+		Body:        deref(r.getString("body")),
+		JD:          r.getFloat("jd"),
+		Dist:        r.getFloat("dist"),
+		DistMin:     r.getFloat("dist_min"),
+		DistMax:     r.getFloat("dist_max"),
+		VRel:        r.getFloat("v_rel"),
+		VInf:        r.getFloat("v_inf"),
+		TSigma:      deref(r.getString("t_sigma_f")),
+
+		H:             r.getFloat("h"),
+		Diameter:      r.getFloat("diameter"),
+		DiameterSigma: r.getFloat("diameter_sigma"),
+	}
+	if s := r.getString("orbit_id"); s != nil {
+		ca.OrbitID = *s
+	} else if s := r.getString("orbit_ref"); s != nil {
+		ca.OrbitID = *s
+	}
+	return ca
+}
+
+// deref returns *p, or the zero value if p is nil.
+func deref[T any](p *T) T {
+	if p == nil {
+		var zero T
+		return zero
+	}
+	return *p
+}
@@ -0,0 +1,232 @@
+package sbdb
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Cache stores raw response bodies keyed by the canonical request URL
+// produced by Client.GetURL. Implementations must be safe for concurrent
+// use.
+type Cache interface {
+	// Get returns the value stored under key and whether an unexpired
+	// entry was found.
+	Get(key string) ([]byte, bool)
+	// Set stores value under key. A ttl of zero or less means the entry
+	// never expires.
+	Set(key string, value []byte, ttl time.Duration) error
+}
+
+type cacheBypassKey struct{}
+
+// WithoutCache returns a context that makes Client skip cache lookups for
+// requests made with it. Successful responses are still written to the
+// cache, so this can be used to force a refresh.
+func WithoutCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, cacheBypassKey{}, true)
+}
+
+func cacheBypassed(ctx context.Context) bool {
+	b, _ := ctx.Value(cacheBypassKey{}).(bool)
+	return b
+}
+
+// cachedGet serves u from c.Cache when possible, otherwise fetches it and
+// stores the response body in the cache unless cacheable rejects it.
+func (c *Client) cachedGet(ctx context.Context, u *url.URL, cacheable func([]byte) bool) (*http.Response, error) {
+	key := u.String()
+	if !cacheBypassed(ctx) {
+		if b, ok := c.Cache.Get(key); ok {
+			log.Debug("Cache hit", "url", key)
+			return cachedResponse(ctx, key, b)
+		}
+	}
+
+	resp, err := c.do(ctx, u)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading response: %w", err)
+	}
+	if cacheable != nil && !cacheable(b) {
+		log.Debug("Response not cached", "url", key)
+	} else if err := c.Cache.Set(key, b, c.CacheTTL); err != nil {
+		log.Debug("Cache write failed", "url", key, "err", err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(b))
+	resp.ContentLength = int64(len(b))
+	return resp, nil
+}
+
+func cachedResponse(ctx context.Context, u string, b []byte) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", u, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Length": []string{strconv.Itoa(len(b))}},
+		Body:          io.NopCloser(bytes.NewReader(b)),
+		ContentLength: int64(len(b)),
+		Request:       req,
+	}, nil
+}
+
+// MemoryCache is an in-memory least-recently-used Cache.
+type MemoryCache struct {
+	mu         sync.Mutex
+	maxEntries int
+	ll         *list.List
+	items      map[string]*list.Element
+}
+
+type memoryEntry struct {
+	key     string
+	value   []byte
+	expires time.Time
+}
+
+// NewMemoryCache returns a MemoryCache holding at most maxEntries
+// responses. If maxEntries is zero or less, the cache is unbounded.
+func NewMemoryCache(maxEntries int) *MemoryCache {
+	return &MemoryCache{
+		maxEntries: maxEntries,
+		ll:         list.New(),
+		items:      make(map[string]*list.Element),
+	}
+}
+
+// Get implements Cache.
+func (m *MemoryCache) Get(key string) ([]byte, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	el, ok := m.items[key]
+	if !ok {
+		return nil, false
+	}
+	e := el.Value.(*memoryEntry)
+	if !e.expires.IsZero() && time.Now().After(e.expires) {
+		m.ll.Remove(el)
+		delete(m.items, key)
+		return nil, false
+	}
+	m.ll.MoveToFront(el)
+	return e.value, true
+}
+
+// Set implements Cache.
+func (m *MemoryCache) Set(key string, value []byte, ttl time.Duration) error {
+	var expires time.Time
+	if ttl > 0 {
+		expires = time.Now().Add(ttl)
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if el, ok := m.items[key]; ok {
+		e := el.Value.(*memoryEntry)
+		e.value, e.expires = value, expires
+		m.ll.MoveToFront(el)
+		return nil
+	}
+	m.items[key] = m.ll.PushFront(&memoryEntry{key: key, value: value, expires: expires})
+	if m.maxEntries > 0 && m.ll.Len() > m.maxEntries {
+		oldest := m.ll.Back()
+		m.ll.Remove(oldest)
+		delete(m.items, oldest.Value.(*memoryEntry).key)
+	}
+	return nil
+}
+
+// Len returns the number of entries in the cache, including any that have
+// expired but not yet been evicted.
+func (m *MemoryCache) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.ll.Len()
+}
+
+// DiskCache is a Cache that stores one file per entry in a directory,
+// allowing cached responses to survive process restarts.
+type DiskCache struct {
+	dir string
+}
+
+// NewDiskCache returns a DiskCache rooted at dir, creating the directory
+// if needed.
+func NewDiskCache(dir string) (*DiskCache, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("error creating cache directory: %w", err)
+	}
+	return &DiskCache{dir: dir}, nil
+}
+
+func (d *DiskCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(d.dir, hex.EncodeToString(sum[:]))
+}
+
+// Get implements Cache. Expired entries are removed from disk.
+func (d *DiskCache) Get(key string) ([]byte, bool) {
+	p := d.path(key)
+	b, err := os.ReadFile(p)
+	if err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			log.Debug("Cache read failed", "path", p, "err", err)
+		}
+		return nil, false
+	}
+	if len(b) < 8 {
+		return nil, false
+	}
+	if exp := int64(binary.BigEndian.Uint64(b[:8])); exp != 0 && time.Now().UnixNano() > exp {
+		_ = os.Remove(p)
+		return nil, false
+	}
+	return b[8:], true
+}
+
+// Set implements Cache. Each entry is written to a temporary file and
+// renamed into place so concurrent readers never observe partial data.
+func (d *DiskCache) Set(key string, value []byte, ttl time.Duration) error {
+	var hdr [8]byte
+	if ttl > 0 {
+		binary.BigEndian.PutUint64(hdr[:], uint64(time.Now().Add(ttl).UnixNano()))
+	}
+	f, err := os.CreateTemp(d.dir, ".tmp-*")
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(append(hdr[:], value...))
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), d.path(key))
+	}
+	if err != nil {
+		_ = os.Remove(f.Name())
+	}
+	return err
+}
@@ -0,0 +1,187 @@
+package sbdb
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestMemoryCache(t *testing.T) {
+	t.Run("get and set", func(t *testing.T) {
+		m := NewMemoryCache(0)
+		if _, ok := m.Get("a"); ok {
+			t.Fatal("Get() on empty cache returned ok")
+		}
+		if err := m.Set("a", []byte("1"), 0); err != nil {
+			t.Fatalf("Set() error = %v", err)
+		}
+		if got, ok := m.Get("a"); !ok || string(got) != "1" {
+			t.Errorf("Get() = %q, %v, want %q, true", got, ok, "1")
+		}
+	})
+
+	t.Run("evicts least recently used", func(t *testing.T) {
+		m := NewMemoryCache(2)
+		_ = m.Set("a", []byte("1"), 0)
+		_ = m.Set("b", []byte("2"), 0)
+		m.Get("a")
+		_ = m.Set("c", []byte("3"), 0)
+		if _, ok := m.Get("b"); ok {
+			t.Error("expected b to be evicted")
+		}
+		if _, ok := m.Get("a"); !ok {
+			t.Error("expected a to remain")
+		}
+		if m.Len() != 2 {
+			t.Errorf("Len() = %d, want 2", m.Len())
+		}
+	})
+
+	t.Run("expires", func(t *testing.T) {
+		m := NewMemoryCache(0)
+		_ = m.Set("a", []byte("1"), time.Millisecond)
+		time.Sleep(5 * time.Millisecond)
+		if _, ok := m.Get("a"); ok {
+			t.Error("expected entry to expire")
+		}
+		if m.Len() != 0 {
+			t.Errorf("Len() = %d, want 0", m.Len())
+		}
+	})
+}
+
+func TestDiskCache(t *testing.T) {
+	dir := t.TempDir()
+	d, err := NewDiskCache(dir)
+	if err != nil {
+		t.Fatalf("NewDiskCache() error = %v", err)
+	}
+
+	if _, ok := d.Get("a"); ok {
+		t.Fatal("Get() on empty cache returned ok")
+	}
+	if err := d.Set("a", []byte(`{"count":1}`), 0); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	if got, ok := d.Get("a"); !ok || string(got) != `{"count":1}` {
+		t.Errorf("Get() = %q, %v, want %q, true", got, ok, `{"count":1}`)
+	}
+
+	// A second DiskCache over the same directory sees the entry.
+	d2, err := NewDiskCache(dir)
+	if err != nil {
+		t.Fatalf("NewDiskCache() error = %v", err)
+	}
+	if _, ok := d2.Get("a"); !ok {
+		t.Error("expected entry to persist across instances")
+	}
+
+	if err := d.Set("b", []byte("x"), time.Millisecond); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	time.Sleep(5 * time.Millisecond)
+	if _, ok := d.Get("b"); ok {
+		t.Error("expected entry to expire")
+	}
+	if _, err := os.Stat(d.path("b")); !os.IsNotExist(err) {
+		t.Errorf("expected expired entry to be removed, stat error = %v", err)
+	}
+}
+
+func TestClient_Get_Cache(t *testing.T) {
+	var requests int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Query().Get("limit") == "2" {
+			http.Error(w, "boom", http.StatusInternalServerError)
+			return
+		}
+		io.WriteString(w, `{"fields":["spkid"],"data":[["1"]],"count":1}`)
+	}))
+	defer srv.Close()
+
+	c := &Client{Endpoint: srv.URL, Cache: NewMemoryCache(10)}
+	f := Filter{Fields: NewFieldSet(SpkID), Limit: 1}
+	get := func(ctx context.Context, f Filter) (string, error) {
+		resp, err := c.Get(ctx, f)
+		if err != nil {
+			return "", err
+		}
+		defer resp.Body.Close()
+		b, err := io.ReadAll(resp.Body)
+		return string(b), err
+	}
+
+	for i := 0; i < 3; i++ {
+		got, err := get(context.Background(), f)
+		if err != nil {
+			t.Fatalf("Get() error = %v", err)
+		}
+		if got != `{"fields":["spkid"],"data":[["1"]],"count":1}` {
+			t.Errorf("Get() body = %q", got)
+		}
+	}
+	if requests != 1 {
+		t.Errorf("requests = %d, want 1", requests)
+	}
+
+	if _, err := get(WithoutCache(context.Background()), f); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if requests != 2 {
+		t.Errorf("requests after bypass = %d, want 2", requests)
+	}
+
+	f.Limit = 2
+	for i := 0; i < 2; i++ {
+		if _, err := get(context.Background(), f); err == nil {
+			t.Fatal("expected error for http 500")
+		}
+	}
+	if requests != 4 {
+		t.Errorf("requests after errors = %d, want 4 (errors are not cached)", requests)
+	}
+}
+
+func TestClient_getChecked_Cache(t *testing.T) {
+	var requests int
+	srv, _ := apiServerFunc(t, func(q url.Values) (int, string) {
+		requests++
+		return http.StatusOK, q.Get("body")
+	})
+	c := &Client{Cache: NewMemoryCache(10)}
+	cacheable := func(b []byte) bool { return string(b) == "ok" }
+	get := func(body string) string {
+		u, err := url.Parse(srv.URL + "?body=" + body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp, err := c.getChecked(context.Background(), u, cacheable)
+		if err != nil {
+			t.Fatalf("getChecked() error = %v", err)
+		}
+		defer resp.Body.Close()
+		b, _ := io.ReadAll(resp.Body)
+		return string(b)
+	}
+
+	for i := 0; i < 2; i++ {
+		if got := get("failed"); got != "failed" {
+			t.Errorf("getChecked() body = %q, want %q", got, "failed")
+		}
+	}
+	if requests != 2 {
+		t.Errorf("requests = %d, want 2 (rejected bodies are not cached)", requests)
+	}
+	for i := 0; i < 2; i++ {
+		get("ok")
+	}
+	if requests != 3 {
+		t.Errorf("requests = %d, want 3", requests)
+	}
+}
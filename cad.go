@@ -0,0 +1,172 @@
+package sbdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CADEndpoint is the default base URL for the SBDB Close-Approach Data
+// API. It can be overridden via Client.CADEndpoint.
+const CADEndpoint = "https://ssd-api.jpl.nasa.gov/cad.api"
+
+// CADSort selects the order of close-approach results.
+// The zero value uses the API default, which sorts by date.
+type CADSort uint
+
+const (
+	CADSortDefault CADSort = iota
+	CADSortDate            // Time of close approach
+	CADSortDist            // Nominal approach distance
+	CADSortDistMin         // Minimum approach distance
+	CADSortVInf            // Velocity relative to a massless body
+	CADSortVRel            // Relative velocity
+	CADSortH               // Absolute magnitude
+	CADSortObject          // Object designation
+)
+
+var cadSortNames = map[CADSort]string{
+	CADSortDefault: "", CADSortDate: "date", CADSortDist: "dist", CADSortDistMin: "dist-min",
+	CADSortVInf: "v-inf", CADSortVRel: "v-rel", CADSortH: "h", CADSortObject: "object",
+}
+
+func (s CADSort) String() string {
+	if n, ok := cadSortNames[s]; ok {
+		return n
+	}
+	return fmt.Sprintf("Invalid CADSort(%d)", s)
+}
+
+// CADQuery defines the search parameters for the Close-Approach Data API,
+// documented at https://ssd-api.jpl.nasa.gov/doc/cad.html. Zero values
+// leave the API defaults in place: Earth approaches within 0.05 au over
+// the next 60 days.
+type CADQuery struct {
+	DateMin time.Time // Earliest approach time
+	DateMax time.Time // Latest approach time
+	DistMin float64   // Minimum nominal approach distance (au)
+	DistMax float64   // Maximum nominal approach distance (au)
+	HMin    float64   // Minimum absolute magnitude (larger H is smaller)
+	HMax    float64   // Maximum absolute magnitude
+	VInfMin float64   // Minimum V-infinity (km/s)
+	VInfMax float64   // Maximum V-infinity (km/s)
+	// Body is the approached body, e.g. "Earth", "Moon" or "Venus"; the
+	// API defaults to Earth. Use "ALL" for every body.
+	Body string
+
+	SpkID       int    // Only approaches of this object
+	Designation string // Only approaches of this object
+	Kind        KindFilter
+	Class       ClassFilter
+	// NEO limits results to near-Earth objects. The API defaults to true
+	// when NEO is nil; set it to false to include other bodies.
+	NEO      *bool
+	PHA      bool // Only potentially hazardous asteroids
+	NEA      bool // Only near-Earth asteroids
+	Comet    bool // Only comets
+	NEAComet bool // Only near-Earth asteroids and near-Earth comets
+
+	// Diameter, when true, includes known diameters in the results.
+	Diameter bool
+	// FullName, when true, includes full object names in the results.
+	FullName bool
+
+	Sort     CADSort
+	SortDesc bool // Sort in descending order
+	Limit    uint
+}
+
+// Values converts q into URL query parameters.
+func (q CADQuery) Values() (url.Values, error) {
+	if q.SpkID != 0 && q.Designation != "" {
+		return nil, errors.New("must not provide both SpkID and Designation")
+	}
+	if err := checkTimeRange("DateMin", q.DateMin, "DateMax", q.DateMax); err != nil {
+		return nil, err
+	}
+	if q.Sort > CADSortObject {
+		return nil, errors.New(q.Sort.String())
+	}
+
+	v := url.Values{}
+	p := params(v)
+	p.setTime("date-min", q.DateMin)
+	p.setTime("date-max", q.DateMax)
+	p.setFloat("dist-min", q.DistMin)
+	p.setFloat("dist-max", q.DistMax)
+	p.setFloat("h-min", q.HMin)
+	p.setFloat("h-max", q.HMax)
+	p.setFloat("v-inf-min", q.VInfMin)
+	p.setFloat("v-inf-max", q.VInfMax)
+	p.setString("body", q.Body)
+	if q.SpkID != 0 {
+		v.Set("spk", strconv.Itoa(q.SpkID))
+	}
+	p.setString("des", q.Designation)
+	if q.Kind > KindAny {
+		v.Set("kind", q.Kind.String())
+	}
+	if q.Class > 0 {
+		v.Set("class", q.Class.String())
+	}
+	if q.NEO != nil {
+		v.Set("neo", strconv.FormatBool(*q.NEO))
+	}
+	p.setBool("pha", q.PHA)
+	p.setBool("nea", q.NEA)
+	p.setBool("comet", q.Comet)
+	p.setBool("nea-comet", q.NEAComet)
+	p.setBool("diameter", q.Diameter)
+	p.setBool("fullname", q.FullName)
+	p.setSort(q.Sort.String(), q.SortDesc)
+	p.setLimit(q.Limit)
+	return v, nil
+}
+
+// CADURL builds a URL for the Close-Approach Data API request represented
+// by q. If Client.CADEndpoint is empty, the default CADEndpoint is used.
+func (c *Client) CADURL(q CADQuery) (*url.URL, error) {
+	return queryURL(c.CADEndpoint, CADEndpoint, q)
+}
+
+// CloseApproaches queries the Close-Approach Data API. The API only
+// reports the approached body when q.Body is "ALL"; otherwise each
+// CloseApproach.Body is set to q.Body, or "Earth" if it is empty.
+func (c *Client) CloseApproaches(ctx context.Context, q CADQuery) ([]CloseApproach, error) {
+	u, err := c.CADURL(q)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.get(ctx, u)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	cas, err := DecodeCAD(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	body := q.Body
+	if body == "" {
+		body = "Earth"
+	}
+	if !strings.EqualFold(body, "ALL") && body != "*" {
+		for i := range cas {
+			if cas[i].Body == "" {
+				cas[i].Body = body
+			}
+		}
+	}
+	return cas, nil
+}
+
+// DecodeCAD parses a Close-Approach Data API payload from r.
+// CloseApproach.Body is empty unless the payload has a body column.
+func DecodeCAD(r io.Reader) ([]CloseApproach, error) {
+	return decodeColumnarAs(r, Record.closeApproach)
+}
@@ -0,0 +1,160 @@
+package sbdb
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+const cadPayload = `{"signature":{"source":"NASA/JPL SBDB Close Approach Data API","version":"1.5"},"count":"2",
+"fields":["des","orbit_id","jd","cd","dist","dist_min","dist_max","v_rel","v_inf","t_sigma_f","h","fullname","diameter","diameter_sigma"],
+"data":[
+	["2024 AB1","5","2460313.5","2024-Jan-01 12:00","0.0123","0.0122","0.0124","10.5","10.4","00:02","25.1","       (2024 AB1)",null,null],
+	["99942","220","2462240.409","2029-Apr-13 21:46","0.000254","0.000254","0.000254","7.42","5.84","< 00:01","19.09","   99942 Apophis (2004 MN4)","0.34","0.04"]
+]}`
+
+func TestCADQuery_Values(t *testing.T) {
+	tests := []struct {
+		name    string
+		q       CADQuery
+		want    url.Values
+		wantErr bool
+	}{
+		{name: "defaults", q: CADQuery{}, want: url.Values{}},
+		{
+			name: "full",
+			q: CADQuery{
+				DateMin: time.Date(2029, 1, 1, 0, 0, 0, 0, time.UTC), DateMax: time.Date(2029, 12, 31, 12, 0, 0, 0, time.UTC),
+				DistMax: 0.01, HMax: 22, VInfMin: 1.5, Body: "ALL", Kind: KindAsteroid, PHA: true, Class: APO,
+				Diameter: true, FullName: true, Sort: CADSortDist, SortDesc: true, Limit: 10,
+			},
+			want: url.Values{
+				"date-min": {"2029-01-01T00:00:00"}, "date-max": {"2029-12-31T12:00:00"}, "dist-max": {"0.01"},
+				"h-max": {"22"}, "v-inf-min": {"1.5"}, "body": {"ALL"}, "kind": {"a"}, "pha": {"true"}, "class": {"APO"},
+				"diameter": {"true"}, "fullname": {"true"}, "sort": {"-dist"}, "limit": {"10"},
+			},
+		},
+		{name: "object", q: CADQuery{SpkID: 2099942, NEAComet: true}, want: url.Values{"spk": {"2099942"}, "nea-comet": {"true"}}},
+		{name: "all objects", q: CADQuery{NEO: ptrTo(false)}, want: url.Values{"neo": {"false"}}},
+		{
+			name: "groups",
+			q:    CADQuery{NEO: ptrTo(true), NEA: true, Comet: true, NEAComet: true},
+			want: url.Values{"neo": {"true"}, "nea": {"true"}, "comet": {"true"}, "nea-comet": {"true"}},
+		},
+		{name: "spkid and designation", q: CADQuery{SpkID: 1, Designation: "1"}, wantErr: true},
+		{
+			name:    "dates reversed",
+			q:       CADQuery{DateMin: time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC), DateMax: time.Date(2029, 1, 1, 0, 0, 0, 0, time.UTC)},
+			wantErr: true,
+		},
+		{name: "invalid sort", q: CADQuery{Sort: 99}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.q.Values()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Values() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Values() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDecodeCAD(t *testing.T) {
+	got, err := DecodeCAD(strings.NewReader(cadPayload))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []CloseApproach{
+		{
+			Designation: "2024 AB1", FullName: "(2024 AB1)", OrbitID: "5",
+			Time: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC), JD: ptrTo(2460313.5),
+			Dist: ptrTo(0.0123), DistMin: ptrTo(0.0122), DistMax: ptrTo(0.0124), VRel: ptrTo(10.5), VInf: ptrTo(10.4),
+			TSigma: "00:02", H: ptrTo(25.1),
+		},
+		{
+			Designation: "99942", FullName: "99942 Apophis (2004 MN4)", OrbitID: "220",
+			Time: time.Date(2029, 4, 13, 21, 46, 0, 0, time.UTC), JD: ptrTo(2462240.409),
+			Dist: ptrTo(0.000254), DistMin: ptrTo(0.000254), DistMax: ptrTo(0.000254), VRel: ptrTo(7.42), VInf: ptrTo(5.84),
+			TSigma: "< 00:01", H: ptrTo(19.09), Diameter: ptrTo(0.34), DiameterSigma: ptrTo(0.04),
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("DecodeCAD() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestDecodeCAD_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"invalid json", `{`},
+		{"invalid count", `{"count":"many"}`},
+		{"short row", `{"fields":["des","jd"],"data":[["1"]]}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := DecodeCAD(strings.NewReader(tt.input)); err == nil {
+				t.Error("DecodeCAD() error = nil, want error")
+			}
+		})
+	}
+	got, err := DecodeCAD(strings.NewReader(`{"signature":{},"count":"0"}`))
+	if err != nil || len(got) != 0 {
+		t.Errorf("DecodeCAD(empty) = %v, %v", got, err)
+	}
+}
+
+func TestClient_CloseApproaches(t *testing.T) {
+	srv, query := apiServer(t, http.StatusOK, cadPayload)
+	c := &Client{CADEndpoint: srv.URL}
+	got, err := c.CloseApproaches(context.Background(), CADQuery{Designation: "99942"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 {
+		t.Errorf("CloseApproaches() returned %d approaches, want 2", len(got))
+	}
+	if q := query.Get("des"); q != "99942" {
+		t.Errorf("des = %q, want 99942", q)
+	}
+}
+
+func TestClient_CloseApproaches_Body(t *testing.T) {
+	allPayload := `{"count":"2","fields":["des","body"],"data":[["433","Mars"],["99942","Earth"]]}`
+	tests := []struct {
+		name    string
+		payload string
+		body    string
+		want    []string
+	}{
+		{"default", cadPayload, "", []string{"Earth", "Earth"}},
+		{"moon", cadPayload, "Moon", []string{"Moon", "Moon"}},
+		{"all", allPayload, "ALL", []string{"Mars", "Earth"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, _ := apiServer(t, http.StatusOK, tt.payload)
+			c := &Client{CADEndpoint: srv.URL}
+			cas, err := c.CloseApproaches(context.Background(), CADQuery{Body: tt.body})
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, ca := range cas {
+				got = append(got, ca.Body)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("CloseApproach.Body mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
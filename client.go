@@ -2,22 +2,56 @@ package sbdb
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"time"
 )
 
 // Endpoint is the default base URL for the SBDB Query API.
 // It can be overridden via Client.Endpoint for testing or custom servers.
 const Endpoint = "https://ssd-api.jpl.nasa.gov/sbdb_query.api"
 
+// LookupEndpoint is the default base URL for the SBDB object API, which
+// returns the full record of a single body. It can be overridden via
+// Client.LookupEndpoint.
+const LookupEndpoint = "https://ssd-api.jpl.nasa.gov/sbdb.api"
+
 // Client wraps http.Client and provides helpers for interacting with
 // the SBDB Query API. The Client.Endpoint field can be set to use a
 // custom API server.
 type Client struct {
 	http.Client
 	Endpoint string
+	// LookupEndpoint, if set, replaces the default LookupEndpoint used
+	// by Lookup.
+	LookupEndpoint string
+	// CADEndpoint, if set, replaces the default CADEndpoint used by
+	// CloseApproaches.
+	CADEndpoint string
+	// SentryEndpoint, if set, replaces the default SentryEndpoint used
+	// by SentryList and SentryObject.
+	SentryEndpoint string
+	// FireballEndpoint, if set, replaces the default FireballEndpoint
+	// used by Fireballs.
+	FireballEndpoint string
+	// ScoutEndpoint, if set, replaces the default ScoutEndpoint used by
+	// ScoutList and ScoutObject.
+	ScoutEndpoint string
+	// Retry configures retries of failed requests. A nil Retry sends
+	// each request exactly once.
+	Retry *RetryPolicy
+	// Limiter, if set, throttles every request made by the Client,
+	// including retries. It may be shared by concurrent callers.
+	Limiter *Limiter
+	// Cache, if set, stores successful responses keyed by request URL.
+	// Use WithoutCache to skip the lookup for a single request.
+	Cache Cache
+	// CacheTTL is how long cached responses remain valid. Zero means
+	// they never expire.
+	CacheTTL time.Duration
 }
 
 // Get issues a GET request using the provided Filter.
@@ -27,24 +61,72 @@ func (c *Client) Get(ctx context.Context, f Filter) (*http.Response, error) {
 	if err != nil {
 		return nil, err
 	}
+	return c.get(ctx, u)
+}
 
-	req, err := http.NewRequestWithContext(ctx, "GET", u.String(), nil)
-	if err != nil {
-		return nil, err
+// get issues a GET request for u, consulting c.Cache first if set. Every
+// request made by the Client goes through get or getChecked.
+func (c *Client) get(ctx context.Context, u *url.URL) (*http.Response, error) {
+	return c.getChecked(ctx, u, nil)
+}
+
+// getChecked is get for APIs that report some failures in a 2xx response.
+// A fetched body is only stored in c.Cache if cacheable is nil or reports
+// true for it.
+func (c *Client) getChecked(ctx context.Context, u *url.URL, cacheable func(body []byte) bool) (*http.Response, error) {
+	if c.Cache != nil {
+		return c.cachedGet(ctx, u, cacheable)
 	}
+	return c.do(ctx, u)
+}
 
-	resp, err := c.Client.Do(req)
+// do issues a GET request for u, retrying according to c.Retry. The
+// returned response always has a 2xx status code.
+func (c *Client) do(ctx context.Context, u *url.URL) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", u.String(), nil)
 	if err != nil {
 		return nil, err
 	}
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		// Optional: Read response body into a string or bytes for debugging
-		defer resp.Body.Close()
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("unexpected status %d: %s", resp.StatusCode, string(body))
-	}
 
-	return resp, nil
+	attempts := c.Retry.maxAttempts()
+	for attempt := 1; ; attempt++ {
+		release, err := c.Limiter.Wait(ctx)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := c.Client.Do(req)
+		if err == nil && resp.StatusCode >= 200 && resp.StatusCode < 300 {
+			resp.Body = &releaseOnClose{ReadCloser: resp.Body, release: release}
+			return resp, nil
+		}
+
+		var retry bool
+		if err != nil {
+			retry = ctx.Err() == nil
+		} else {
+			body, _ := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			err = newAPIError(u.String(), resp.StatusCode, body)
+			retry = c.Retry != nil && c.Retry.retryable(resp.StatusCode)
+		}
+		release()
+		if !retry || attempt >= attempts {
+			if attempt > 1 {
+				err = fmt.Errorf("giving up after %d attempts: %w", attempt, err)
+			}
+			return nil, err
+		}
+
+		wait := c.Retry.backoff(attempt, resp)
+		log.Debug("Retrying request", "url", u.String(), "attempt", attempt, "wait", wait, "err", err)
+		t := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return nil, ctx.Err()
+		case <-t.C:
+		}
+	}
 }
 
 // GetURL builds a URL for the request represented by the Filter. If
@@ -54,14 +136,149 @@ func (c *Client) GetURL(f Filter) (*url.URL, error) {
 	if err != nil {
 		return nil, fmt.Errorf("error parsing filter: %w", err)
 	}
-	ep := Endpoint
-	if c.Endpoint != "" {
-		ep = c.Endpoint
+	u, err := c.endpointURL()
+	if err != nil {
+		return nil, err
+	}
+	u.RawQuery = v.Encode()
+	return u, nil
+}
+
+// endpointURL parses Client.Endpoint, or the default Endpoint if unset.
+func (c *Client) endpointURL() (*url.URL, error) {
+	return parseEndpoint(c.Endpoint, Endpoint)
+}
+
+// parseEndpoint parses ep, or def if ep is empty.
+func parseEndpoint(ep, def string) (*url.URL, error) {
+	if ep == "" {
+		ep = def
 	}
 	u, err := url.Parse(ep)
 	if err != nil {
 		return nil, fmt.Errorf("error parsing endpoint: %w", err)
 	}
+	return u, nil
+}
+
+// queryURL builds a URL for the request represented by q against ep, or
+// def if ep is empty.
+func queryURL(ep, def string, q interface{ Values() (url.Values, error) }) (*url.URL, error) {
+	v, err := q.Values()
+	if err != nil {
+		return nil, fmt.Errorf("error parsing query: %w", err)
+	}
+	u, err := parseEndpoint(ep, def)
+	if err != nil {
+		return nil, err
+	}
 	u.RawQuery = v.Encode()
 	return u, nil
 }
+
+// Iterator walks every Body matched by a Filter, transparently issuing
+// successive limit/limit-from requests. Create one with Client.Iterate.
+// An Iterator is not safe for concurrent use.
+type Iterator struct {
+	c         *Client
+	ctx       context.Context
+	f         Filter
+	pageSize  uint
+	offset    uint
+	remaining uint // bodies left when the Filter sets a Limit
+	limited   bool
+	page      *Payload
+	bodies    []Body
+	body      Body
+	done      bool
+	err       error
+}
+
+// Iterate returns an Iterator over the bodies matched by f, requesting
+// pageSize records per request. f.LimitFrom sets the starting offset and a
+// non-zero f.Limit caps the total number of bodies returned. Iteration
+// stops once a page reports a Payload.Count smaller than the page size.
+func (c *Client) Iterate(ctx context.Context, f Filter, pageSize uint) *Iterator {
+	it := &Iterator{
+		c:         c,
+		ctx:       ctx,
+		f:         f,
+		pageSize:  pageSize,
+		offset:    f.LimitFrom,
+		remaining: f.Limit,
+		limited:   f.Limit > 0,
+	}
+	if pageSize == 0 {
+		it.err = errors.New("page size must be greater than zero")
+	}
+	return it
+}
+
+// Next advances to the next Body, fetching a new page when the current one
+// is exhausted. It returns false when iteration is complete or an error
+// occurred; check Err to tell the two apart.
+func (it *Iterator) Next() bool {
+	for len(it.bodies) == 0 {
+		if it.done || it.err != nil {
+			return false
+		}
+		it.fetch()
+	}
+	it.body, it.bodies = it.bodies[0], it.bodies[1:]
+	return true
+}
+
+// Body returns the Body at the current position.
+func (it *Iterator) Body() Body {
+	return it.body
+}
+
+// Page returns the most recently fetched Payload, or nil before the first
+// call to Next.
+func (it *Iterator) Page() *Payload {
+	return it.page
+}
+
+// Err returns the first error encountered during iteration.
+func (it *Iterator) Err() error {
+	return it.err
+}
+
+func (it *Iterator) fetch() {
+	limit := it.pageSize
+	if it.limited && it.remaining < limit {
+		limit = it.remaining
+	}
+	f := it.f
+	f.Limit = limit
+	f.LimitFrom = it.offset
+
+	resp, err := it.c.Get(it.ctx, f)
+	if err != nil {
+		it.err = err
+		return
+	}
+	defer resp.Body.Close()
+
+	p, err := Decode(resp.Body)
+	if err != nil {
+		it.err = fmt.Errorf("page at offset %d: %w", it.offset, err)
+		return
+	}
+	bodies, err := p.Bodies()
+	if err != nil {
+		it.err = fmt.Errorf("page at offset %d: %w", it.offset, err)
+		return
+	}
+
+	n := uint(len(bodies))
+	it.page = p
+	it.bodies = bodies
+	it.offset += n
+	if it.limited {
+		it.remaining -= min(n, it.remaining)
+	}
+	if n == 0 || n < limit || uint(p.Count) < limit || (it.limited && it.remaining == 0) {
+		it.done = true
+	}
+}
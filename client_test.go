@@ -2,10 +2,13 @@ package sbdb
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -146,11 +149,120 @@ func TestClient_Get(t *testing.T) {
 
 		c := &Client{Client: http.Client{Transport: rt}, Endpoint: "http://example.com"}
 		_, err := c.Get(context.Background(), Filter{Fields: NewFieldSet(SpkID), Limit: 1})
-		if err == nil {
-			t.Fatal("expected error for http 500")
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("Get() error = %v, want *APIError", err)
+		}
+		if apiErr.StatusCode != http.StatusInternalServerError || string(apiErr.Body) != "boom" {
+			t.Errorf("APIError = %+v, want status 500 with body %q", apiErr, "boom")
 		}
 		if rc != nil && !rc.closed {
 			t.Error("expected response body to be closed")
 		}
 	})
 }
+
+// pagedResponse returns an apiServerFunc responder serving total records
+// of the form [spkid] honoring the limit and limit-from query parameters.
+// It counts the requests it receives in requests.
+func pagedResponse(total int, requests *int) func(url.Values) (int, string) {
+	return func(q url.Values) (int, string) {
+		*requests++
+		limit, _ := strconv.Atoi(q.Get("limit"))
+		from, _ := strconv.Atoi(q.Get("limit-from"))
+		var rows []string
+		for i := from; i < total && i < from+limit; i++ {
+			rows = append(rows, fmt.Sprintf(`["%d"]`, i))
+		}
+		return http.StatusOK, fmt.Sprintf(`{"fields":["spkid"],"data":[%s],"count":%d}`, strings.Join(rows, ","), len(rows))
+	}
+}
+
+func TestClient_Iterate(t *testing.T) {
+	tests := []struct {
+		name         string
+		total        int
+		filter       Filter
+		pageSize     uint
+		want         []int
+		wantRequests int
+	}{
+		{
+			name:         "all pages",
+			total:        5,
+			filter:       Filter{Fields: NewFieldSet(SpkID)},
+			pageSize:     2,
+			want:         []int{0, 1, 2, 3, 4},
+			wantRequests: 3,
+		},
+		{
+			name:         "exact multiple of page size",
+			total:        4,
+			filter:       Filter{Fields: NewFieldSet(SpkID)},
+			pageSize:     2,
+			want:         []int{0, 1, 2, 3},
+			wantRequests: 3,
+		},
+		{
+			name:         "limit and offset",
+			total:        10,
+			filter:       Filter{Fields: NewFieldSet(SpkID), Limit: 3, LimitFrom: 4},
+			pageSize:     2,
+			want:         []int{4, 5, 6},
+			wantRequests: 2,
+		},
+		{
+			name:         "empty",
+			total:        0,
+			filter:       Filter{Fields: NewFieldSet(SpkID)},
+			pageSize:     2,
+			want:         nil,
+			wantRequests: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests int
+			srv, _ := apiServerFunc(t, pagedResponse(tt.total, &requests))
+			c := &Client{Endpoint: srv.URL}
+
+			var got []int
+			it := c.Iterate(context.Background(), tt.filter, tt.pageSize)
+			for it.Next() {
+				got = append(got, *it.Body().Identity.SpkID)
+			}
+			if err := it.Err(); err != nil {
+				t.Fatalf("Err() = %v", err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("bodies mismatch (-want +got):\n%s", diff)
+			}
+			if requests != tt.wantRequests {
+				t.Errorf("requests = %d, want %d", requests, tt.wantRequests)
+			}
+		})
+	}
+
+	t.Run("zero page size", func(t *testing.T) {
+		c := &Client{Endpoint: "http://example.com"}
+		it := c.Iterate(context.Background(), Filter{Fields: NewFieldSet(SpkID)}, 0)
+		if it.Next() {
+			t.Fatal("Next() = true, want false")
+		}
+		if it.Err() == nil {
+			t.Fatal("expected error for zero page size")
+		}
+	})
+
+	t.Run("http error", func(t *testing.T) {
+		srv, _ := apiServer(t, http.StatusInternalServerError, "boom")
+		c := &Client{Endpoint: srv.URL}
+		it := c.Iterate(context.Background(), Filter{Fields: NewFieldSet(SpkID)}, 2)
+		if it.Next() {
+			t.Fatal("Next() = true, want false")
+		}
+		if it.Err() == nil {
+			t.Fatal("expected error for http 500")
+		}
+	})
+}
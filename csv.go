@@ -0,0 +1,209 @@
+package sbdb
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// BodySource yields Bodies one at a time. Iterator, Stream and CSVReader
+// implement it.
+type BodySource interface {
+	// Next advances to the next Body, returning false when there are no
+	// more or on error.
+	Next() bool
+	// Body returns the current Body.
+	Body() Body
+	// Err returns the error, if any, that stopped Next.
+	Err() error
+}
+
+// CSVWriter writes records as comma- or tab-separated values, one column
+// per field. The first row is a header of field names. Values are written
+// as the API renders them, so numbers keep their exact decimal text and
+// booleans are "Y" or "N"; null values are empty cells.
+//
+// Rows are buffered; call Flush when done.
+type CSVWriter struct {
+	w      *csv.Writer
+	fields []Field
+	header bool
+}
+
+// NewCSVWriter returns a CSVWriter writing comma-separated values to w
+// with the given columns, in order.
+func NewCSVWriter(w io.Writer, fields []Field) *CSVWriter {
+	return &CSVWriter{w: csv.NewWriter(w), fields: fields}
+}
+
+// NewTSVWriter returns a CSVWriter writing tab-separated values to w with
+// the given columns, in order.
+func NewTSVWriter(w io.Writer, fields []Field) *CSVWriter {
+	cw := NewCSVWriter(w, fields)
+	cw.w.Comma = '\t'
+	return cw
+}
+
+// WriteRecord writes r as a single row. Fields missing from r are empty.
+func (w *CSVWriter) WriteRecord(r Record) error {
+	if err := w.writeHeader(); err != nil {
+		return err
+	}
+	row := make([]string, len(w.fields))
+	for i, f := range w.fields {
+		row[i], _ = FormatValue(r[f])
+	}
+	return w.w.Write(row)
+}
+
+// WriteBody writes b as a single row.
+func (w *CSVWriter) WriteBody(b Body) error {
+	return w.WriteRecord(b.Record())
+}
+
+// WritePayload writes every row of p. Values are taken from the payload
+// data directly, so numbers are written exactly as the API returned them.
+func (w *CSVWriter) WritePayload(p *Payload) error {
+	if err := w.writeHeader(); err != nil {
+		return err
+	}
+	for i, row := range p.Data {
+		r, err := newRecord(p.Fields, row, i)
+		if err != nil {
+			return err
+		}
+		if err := w.WriteRecord(r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteBodies writes every Body from src, returning src's error if it
+// stops early.
+func (w *CSVWriter) WriteBodies(src BodySource) error {
+	if err := w.writeHeader(); err != nil {
+		return err
+	}
+	for src.Next() {
+		if err := w.WriteBody(src.Body()); err != nil {
+			return err
+		}
+	}
+	return src.Err()
+}
+
+// Flush writes any buffered rows, and the header if nothing else has
+// been written, to the underlying writer.
+func (w *CSVWriter) Flush() error {
+	if err := w.writeHeader(); err != nil {
+		return err
+	}
+	w.w.Flush()
+	return w.w.Error()
+}
+
+func (w *CSVWriter) writeHeader() error {
+	if w.header {
+		return nil
+	}
+	w.header = true
+	row := make([]string, len(w.fields))
+	for i, f := range w.fields {
+		row[i] = f.String()
+	}
+	return w.w.Write(row)
+}
+
+// CSVReader reads Records from comma- or tab-separated values written by
+// CSVWriter. The first row must be a header of field names. Cells are kept
+// as strings, as in API responses, and empty cells are null, so Body
+// converts them with the same rules as Payload.Bodies.
+//
+// A CSVReader is not safe for concurrent use.
+type CSVReader struct {
+	r      *csv.Reader
+	fields []string
+	row    int
+	rec    Record
+	err    error
+}
+
+// NewCSVReader returns a CSVReader reading comma-separated values from r.
+func NewCSVReader(r io.Reader) *CSVReader {
+	return &CSVReader{r: csv.NewReader(r)}
+}
+
+// NewTSVReader returns a CSVReader reading tab-separated values from r.
+func NewTSVReader(r io.Reader) *CSVReader {
+	cr := NewCSVReader(r)
+	cr.r.Comma = '\t'
+	return cr
+}
+
+// Next advances to the next row. It returns false at the end of the input
+// or on error; check Err to tell the two apart.
+func (r *CSVReader) Next() bool {
+	if r.err != nil {
+		return false
+	}
+	if r.fields == nil {
+		header, err := r.r.Read()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				err = errors.New("missing header row")
+			}
+			r.fail(err)
+			return false
+		}
+		r.fields = header
+	}
+	cells, err := r.r.Read()
+	if errors.Is(err, io.EOF) {
+		return false
+	}
+	if err != nil {
+		r.fail(err)
+		return false
+	}
+	row := make([]any, len(cells))
+	for i, c := range cells {
+		if c != "" {
+			row[i] = c
+		}
+	}
+	rec, err := newRecord(r.fields, row, r.row)
+	if err != nil {
+		r.fail(err)
+		return false
+	}
+	r.rec = rec
+	r.row++
+	return true
+}
+
+// Record returns the current row as a Record.
+func (r *CSVReader) Record() Record {
+	return r.rec
+}
+
+// Body returns the current row converted to a Body.
+func (r *CSVReader) Body() Body {
+	return r.rec.body()
+}
+
+// Err returns the first error encountered while reading.
+func (r *CSVReader) Err() error {
+	return r.err
+}
+
+// Fields returns the field names from the header row, once it has been
+// read.
+func (r *CSVReader) Fields() []string {
+	return r.fields
+}
+
+func (r *CSVReader) fail(err error) {
+	r.err = fmt.Errorf("decode failed: %w", err)
+}
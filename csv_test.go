@@ -0,0 +1,154 @@
+package sbdb
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+var (
+	_ BodySource = (*Iterator)(nil)
+	_ BodySource = (*Stream)(nil)
+	_ BodySource = (*CSVReader)(nil)
+)
+
+func TestCSVWriter_WritePayload(t *testing.T) {
+	p, err := Decode(strings.NewReader(`{"fields":["spkid","full_name","e","neo"],"data":[
+		["2000433","   433 Eros (A898 PA)","0.22283594070716281","Y"],
+		["2000001","Ceres, \"the\" dwarf",null,"N"]
+	],"count":2}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name      string
+		newWriter func(*bytes.Buffer) *CSVWriter
+		want      string
+	}{
+		{
+			name: "csv",
+			newWriter: func(b *bytes.Buffer) *CSVWriter {
+				return NewCSVWriter(b, []Field{SpkID, FullName, Eccentricity, NEO, PHA})
+			},
+			want: "spkid,full_name,e,neo,pha\n" +
+				"2000433,\"   433 Eros (A898 PA)\",0.22283594070716281,Y,\n" +
+				"2000001,\"Ceres, \"\"the\"\" dwarf\",,N,\n",
+		},
+		{
+			name: "tsv",
+			newWriter: func(b *bytes.Buffer) *CSVWriter {
+				return NewTSVWriter(b, []Field{NEO, SpkID})
+			},
+			want: "neo\tspkid\nY\t2000433\nN\t2000001\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			w := tt.newWriter(&buf)
+			if err := w.WritePayload(p); err != nil {
+				t.Fatal(err)
+			}
+			if err := w.Flush(); err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tt.want, buf.String()); diff != "" {
+				t.Errorf("WritePayload() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCSVWriter_Flush_HeaderOnly(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewCSVWriter(&buf, []Field{SpkID, H})
+	if err := w.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := buf.String(), "spkid,H\n"; got != want {
+		t.Errorf("Flush() wrote %q, want %q", got, want)
+	}
+}
+
+func TestCSV_RoundTrip(t *testing.T) {
+	bodies := []Body{
+		{
+			Identity: Identity{SpkID: ptrTo(2000433), FullName: ptrTo("433 Eros (A898 PA)"), NEO: ptrTo(true)},
+			Orbit:    Orbit{Eccentricity: ptrTo(0.2228359407071628), Inclination: ptrTo(10.82)},
+			Physical: Physical{H: ptrTo(10.39), Diameter: ptrTo(16.84)},
+		},
+		{Identity: Identity{SpkID: ptrTo(2000001), NEO: ptrTo(false)}, Orbit: Orbit{Epoch: ptrTo(2459600.5)}},
+	}
+	for _, tsv := range []bool{false, true} {
+		var buf bytes.Buffer
+		w, newReader := NewCSVWriter(&buf, AllFields()), NewCSVReader
+		if tsv {
+			w, newReader = NewTSVWriter(&buf, AllFields()), NewTSVReader
+		}
+		src := NewStream(bytes.NewReader(encodeBodies(t, bodies)))
+		if err := w.WriteBodies(src); err != nil {
+			t.Fatal(err)
+		}
+		if err := w.Flush(); err != nil {
+			t.Fatal(err)
+		}
+		r := newReader(&buf)
+		var got []Body
+		for r.Next() {
+			got = append(got, r.Body())
+		}
+		if err := r.Err(); err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(bodies, got); diff != "" {
+			t.Errorf("round trip (tsv=%v) mismatch (-want +got):\n%s", tsv, diff)
+		}
+	}
+}
+
+func encodeBodies(t *testing.T, bodies []Body) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := NewPayloadBodies(AllFields(), bodies).Encode(&buf); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestCSVReader(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    []Record
+		wantErr bool
+	}{
+		{
+			name:  "valid",
+			input: "spkid,neo,e\n1,Y,0.5\n2,,\n",
+			want: []Record{
+				{SpkID: "1", NEO: "Y", Eccentricity: "0.5"},
+				{SpkID: "2", NEO: nil, Eccentricity: nil},
+			},
+		},
+		{name: "header only", input: "spkid\n"},
+		{name: "empty", input: "", wantErr: true},
+		{name: "short row", input: "spkid,neo\n1\n", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewCSVReader(strings.NewReader(tt.input))
+			var got []Record
+			for r.Next() {
+				got = append(got, r.Record())
+			}
+			if (r.Err() != nil) != tt.wantErr {
+				t.Fatalf("Err() = %v, wantErr %v", r.Err(), tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Record() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -6,8 +6,10 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"reflect"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // Decode parses an SBDB JSON payload from r.
@@ -29,15 +31,65 @@ func Decode(r io.Reader) (*Payload, error) {
 	return &p, nil
 }
 
+// decodeColumnar parses a fields/data payload from one of the other JPL
+// SSD APIs, which share the layout of SBDB payloads but may report the
+// count as a string.
+func decodeColumnar(r io.Reader) (*Payload, error) {
+	if r == nil {
+		return nil, errors.New("nil reader")
+	}
+	var raw struct {
+		Signature Signature   `json:"signature"`
+		Fields    []string    `json:"fields"`
+		Data      [][]any     `json:"data"`
+		Count     json.Number `json:"count"`
+	}
+	dec := json.NewDecoder(bufio.NewReader(r))
+	dec.UseNumber()
+	if err := dec.Decode(&raw); err != nil {
+		return nil, fmt.Errorf("decode failed: %w", err)
+	}
+	p := &Payload{Signature: raw.Signature, Fields: raw.Fields, Data: raw.Data}
+	if raw.Count != "" {
+		n, err := raw.Count.Int64()
+		if err != nil {
+			return nil, fmt.Errorf("decode failed: invalid count %q", raw.Count)
+		}
+		p.Count = int(n)
+	}
+	return p, nil
+}
+
+// decodeColumnarAs parses a payload with decodeColumnar and converts each
+// record with conv.
+func decodeColumnarAs[T any](r io.Reader, conv func(Record) T) ([]T, error) {
+	p, err := decodeColumnar(r)
+	if err != nil {
+		return nil, err
+	}
+	records, err := p.Records()
+	if err != nil {
+		return nil, err
+	}
+	out := make([]T, len(records))
+	for i, rec := range records {
+		out[i] = conv(rec)
+	}
+	return out, nil
+}
+
+// Signature identifies the API source and version that produced a payload.
+type Signature struct {
+	Version string `json:"version"`
+	Source  string `json:"source"`
+}
+
 // Payload is a raw SBDB response containing records and metadata.
 type Payload struct {
-	Signature struct {
-		Version string `json:"version"`
-		Source  string `json:"source"`
-	} `json:"signature"`
-	Fields []string `json:"fields"`
-	Data   [][]any  `json:"data"`
-	Count  int      `json:"count"`
+	Signature Signature `json:"signature"`
+	Fields    []string  `json:"fields"`
+	Data      [][]any   `json:"data"`
+	Count     int       `json:"count"`
 }
 
 // Records returns the payload data as a slice of generic Records.
@@ -46,18 +98,28 @@ type Payload struct {
 func (p *Payload) Records() ([]Record, error) {
 	records := make([]Record, len(p.Data))
 	for i, b := range p.Data {
-		if len(b) != len(p.Fields) {
-			return nil, fmt.Errorf("data element %d has %d fields, expected %d", i, len(b), len(p.Fields))
-		}
-		records[i] = make(Record)
-		for j, v := range b {
-			records[i][Field(p.Fields[j])] = v
+		r, err := newRecord(p.Fields, b, i)
+		if err != nil {
+			return nil, err
 		}
+		records[i] = r
 	}
 
 	return records, nil
 }
 
+// newRecord pairs the values of data row i with their field names.
+func newRecord(fields []string, row []any, i int) (Record, error) {
+	if len(row) != len(fields) {
+		return nil, fmt.Errorf("data element %d has %d fields, expected %d", i, len(row), len(fields))
+	}
+	r := make(Record, len(fields))
+	for j, v := range row {
+		r[Field(fields[j])] = v
+	}
+	return r, nil
+}
+
 // Bodies converts the payload data into strongly typed Body structs.
 func (p *Payload) Bodies() ([]Body, error) {
 	records, err := p.Records()
@@ -66,15 +128,7 @@ func (p *Payload) Bodies() ([]Body, error) {
 	}
 	bodies := make([]Body, len(records))
 	for i, r := range records {
-		bodies[i] = Body{
-			Identity:    r.identity(),
-			Orbit:       r.orbit(),
-			Uncertainty: r.uncertainty(),
-			Solution:    r.solution(),
-			Quality:     r.quality(),
-			NonGrav:     r.nonGrav(),
-			Physical:    r.physical(),
-		}
+		bodies[i] = r.body()
 	}
 	return bodies, nil
 }
@@ -82,6 +136,38 @@ func (p *Payload) Bodies() ([]Body, error) {
 // Record represents a single result row as a map of field names to values.
 type Record map[Field]any
 
+func (r Record) body() Body {
+	return Body{
+		Identity:    r.identity(),
+		Orbit:       r.orbit(),
+		Uncertainty: r.uncertainty(),
+		Solution:    r.solution(),
+		Quality:     r.quality(),
+		NonGrav:     r.nonGrav(),
+		Physical:    r.physical(),
+	}
+}
+
+// Record converts b back into a Record keyed by field name, the inverse of
+// Payload.Bodies. Every Body field is present; unset fields map to nil.
+// Values are the dereferenced Go values (int, float64, string or bool).
+func (b Body) Record() Record {
+	r := make(Record)
+	v := reflect.ValueOf(b)
+	for i := 0; i < v.NumField(); i++ {
+		group := v.Field(i)
+		for j := 0; j < group.NumField(); j++ {
+			name, _, _ := strings.Cut(group.Type().Field(j).Tag.Get("json"), ",")
+			if f := group.Field(j); !f.IsNil() {
+				r[Field(name)] = f.Elem().Interface()
+			} else {
+				r[Field(name)] = nil
+			}
+		}
+	}
+	return r
+}
+
 func (r Record) identity() Identity {
 	return Identity{
 		SpkID:       r.getInt(SpkID),
@@ -218,6 +304,11 @@ func (r Record) getFloat(field Field) *float64 {
 			return nil
 		}
 		return &f
+	case float64:
+		return &v
+	case int:
+		f := float64(v)
+		return &f
 	default:
 		logFailedTypeAssert("getFloat", field, r[field])
 		return nil
@@ -252,6 +343,11 @@ func (r Record) getInt(field Field) *int {
 			return nil
 		}
 		return &i
+	case int:
+		return &v
+	case float64:
+		i := int(v)
+		return &i
 	default:
 		logFailedTypeAssert("getInt", field, r[field])
 		return nil
@@ -300,3 +396,15 @@ func (r Record) getBool(field Field) *bool {
 		return nil
 	}
 }
+
+func (r Record) getTime(field Field) *time.Time {
+	if r[field] == nil {
+		return nil
+	}
+	t, err := parseTime(r[field])
+	if err != nil {
+		logFailedTypeAssert("getTime", field, r[field])
+		return nil
+	}
+	return &t
+}
@@ -8,6 +8,9 @@ import (
 	"os"
 	"reflect"
 	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
 )
 
 func TestMain(m *testing.M) {
@@ -340,6 +343,22 @@ func TestRecord_getFloat(t *testing.T) {
 			args: args{"num"},
 			want: ptrTo(3.0),
 		},
+		{
+			name: "Float",
+			r: Record{
+				"float": 3.14159,
+			},
+			args: args{"float"},
+			want: ptrTo(3.14159),
+		},
+		{
+			name: "Int",
+			r: Record{
+				"int": 3,
+			},
+			args: args{"int"},
+			want: ptrTo(3.0),
+		},
 		{
 			name: "Bool",
 			r: Record{
@@ -408,6 +427,22 @@ func TestRecord_getInt(t *testing.T) {
 			args: args{"num"},
 			want: ptrTo(3),
 		},
+		{
+			name: "Int",
+			r: Record{
+				"int": 3,
+			},
+			args: args{"int"},
+			want: ptrTo(3),
+		},
+		{
+			name: "Float",
+			r: Record{
+				"float": 3.14159,
+			},
+			args: args{"float"},
+			want: ptrTo(3),
+		},
 		{
 			name: "Bool",
 			r: Record{
@@ -510,6 +545,27 @@ func TestRecord_getString(t *testing.T) {
 	}
 }
 
+func TestRecord_getTime(t *testing.T) {
+	tests := []struct {
+		name string
+		r    Record
+		want *time.Time
+	}{
+		{"calendar", Record{"t": "2024-01-21 00:32:38"}, ptrTo(time.Date(2024, 1, 21, 0, 32, 38, 0, time.UTC))},
+		{"julian", Record{"t": json.Number("2451545.0")}, ptrTo(time.Date(2000, 1, 1, 12, 0, 0, 0, time.UTC))},
+		{"invalid", Record{"t": "soon"}, nil},
+		{"nil", Record{"t": nil}, nil},
+		{"missing", Record{}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := cmp.Diff(tt.want, tt.r.getTime("t")); diff != "" {
+				t.Errorf("getTime() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func FuzzBodies(f *testing.F) {
 	f.Add([]byte(`{"fields":["spkid","full_name","neo","t_jup"],"data":[[1234,"name","Y","3.14"]]}`)) // realistic, full
 	f.Add([]byte(`{"fields":["spkid"],"data":[[1234]]}`))                                             // minimal int
@@ -534,3 +590,21 @@ func FuzzBodies(f *testing.F) {
 func ptrTo[T any](v T) *T {
 	return &v
 }
+
+func TestBody_Record(t *testing.T) {
+	b := Body{
+		Identity: Identity{SpkID: ptrTo(20000433), FullName: ptrTo("433 Eros (A898 PA)"), NEO: ptrTo(true)},
+		Orbit:    Orbit{Eccentricity: ptrTo(0.2228)},
+		Physical: Physical{Extent: ptrTo("34.4x11.2x11.2")},
+	}
+	r := b.Record()
+	if got := len(r); got != len(IdentityFields())+len(OrbitFields())+len(UncertaintyFields())+len(SolutionFields())+len(NonGravFields())+len(PhysicalFields()) {
+		t.Errorf("len(Record()) = %d, want every field", got)
+	}
+	if r[SpkID] != 20000433 || r[NEO] != true || r[Eccentricity] != 0.2228 || r[Albedo] != nil {
+		t.Errorf("Record() = %v", r)
+	}
+	if diff := cmp.Diff(b, r.body()); diff != "" {
+		t.Errorf("round trip mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,97 @@
+package sbdb
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// NewPayload builds a Payload holding records, with one column per field
+// in the given order. Values are rendered as the API renders them: every
+// non-null value becomes a string and booleans become "Y" or "N". Fields
+// missing from a record are null. The Signature is left empty.
+func NewPayload(fields []Field, records []Record) *Payload {
+	p := &Payload{
+		Fields: make([]string, len(fields)),
+		Data:   make([][]any, len(records)),
+		Count:  len(records),
+	}
+	for i, f := range fields {
+		p.Fields[i] = f.String()
+	}
+	for i, r := range records {
+		row := make([]any, len(fields))
+		for j, f := range fields {
+			if s, ok := FormatValue(r[f]); ok {
+				row[j] = s
+			}
+		}
+		p.Data[i] = row
+	}
+	return p
+}
+
+// NewPayloadBodies is like NewPayload but takes Bodies, so that
+// Payload.Bodies on the result returns the same values for the chosen
+// fields.
+func NewPayloadBodies(fields []Field, bodies []Body) *Payload {
+	records := make([]Record, len(bodies))
+	for i, b := range bodies {
+		records[i] = b.Record()
+	}
+	return NewPayload(fields, records)
+}
+
+// Encode writes p to w as JSON in the layout returned by the API, with the
+// signature and count ahead of the fields and data. The output can be read
+// back with Decode or NewStream.
+func (p *Payload) Encode(w io.Writer) error {
+	out := struct {
+		Signature Signature `json:"signature"`
+		Count     int       `json:"count"`
+		Fields    []string  `json:"fields"`
+		Data      [][]any   `json:"data"`
+	}{p.Signature, p.Count, p.Fields, p.Data}
+	if out.Fields == nil {
+		out.Fields = []string{}
+	}
+	if out.Data == nil {
+		out.Data = [][]any{}
+	}
+	if err := json.NewEncoder(w).Encode(out); err != nil {
+		return fmt.Errorf("encode failed: %w", err)
+	}
+	return nil
+}
+
+// FormatValue renders a Record value as the API reports it: numbers in
+// their shortest exact form and booleans as "Y" or "N". It reports false
+// for a nil value, which the API sends as null.
+func FormatValue(v any) (string, bool) {
+	switch v := v.(type) {
+	case nil:
+		return "", false
+	case string:
+		return v, true
+	case json.Number:
+		return v.String(), true
+	case Decimal:
+		return v.String(), true
+	case bool:
+		if v {
+			return "Y", true
+		}
+		return "N", true
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64), true
+	case float32:
+		return strconv.FormatFloat(float64(v), 'g', -1, 32), true
+	case int:
+		return strconv.Itoa(v), true
+	case int64:
+		return strconv.FormatInt(v, 10), true
+	default:
+		return fmt.Sprint(v), true
+	}
+}
@@ -0,0 +1,105 @@
+package sbdb
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestFormatValue(t *testing.T) {
+	tests := []struct {
+		name   string
+		v      any
+		want   string
+		wantOK bool
+	}{
+		{"nil", nil, "", false},
+		{"string", "433 Eros", "433 Eros", true},
+		{"json.Number", json.Number("10.39"), "10.39", true},
+		{"Decimal", Decimal("0.0785000000000000001"), "0.0785000000000000001", true},
+		{"true", true, "Y", true},
+		{"false", false, "N", true},
+		{"float64", 0.2229, "0.2229", true},
+		{"float32", float32(0.1), "0.1", true},
+		{"int", 2000433, "2000433", true},
+		{"int64", int64(-5), "-5", true},
+		{"uint", uint(7), "7", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := FormatValue(tt.v)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("FormatValue(%v) = %q, %v, want %q, %v", tt.v, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestNewPayload(t *testing.T) {
+	records := []Record{
+		{SpkID: 2000433, FullName: "433 Eros", NEO: true, Eccentricity: 0.2229, H: json.Number("10.39")},
+		{SpkID: "2000001", PHA: false, Eccentricity: Decimal("0.0785000000000000001")},
+	}
+	got := NewPayload([]Field{FullName, SpkID, NEO, PHA, Eccentricity, H}, records)
+	want := &Payload{
+		Fields: []string{"full_name", "spkid", "neo", "pha", "e", "H"},
+		Data: [][]any{
+			{"433 Eros", "2000433", "Y", nil, "0.2229", "10.39"},
+			{nil, "2000001", nil, "N", "0.0785000000000000001", nil},
+		},
+		Count: 2,
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("NewPayload() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestPayload_Encode(t *testing.T) {
+	p := NewPayload([]Field{SpkID, NEO}, []Record{{SpkID: 1, NEO: true}})
+	p.Signature = Signature{Version: "1.0", Source: "test"}
+	var buf bytes.Buffer
+	if err := p.Encode(&buf); err != nil {
+		t.Fatal(err)
+	}
+	want := `{"signature":{"version":"1.0","source":"test"},"count":1,"fields":["spkid","neo"],"data":[["1","Y"]]}` + "\n"
+	if got := buf.String(); got != want {
+		t.Errorf("Encode() = %s, want %s", got, want)
+	}
+
+	buf.Reset()
+	if err := (&Payload{}).Encode(&buf); err != nil {
+		t.Fatal(err)
+	}
+	want = `{"signature":{"version":"","source":""},"count":0,"fields":[],"data":[]}` + "\n"
+	if got := buf.String(); got != want {
+		t.Errorf("Encode() = %s, want %s", got, want)
+	}
+}
+
+func TestNewPayloadBodies_RoundTrip(t *testing.T) {
+	bodies := []Body{
+		{
+			Identity: Identity{SpkID: ptrTo(2000433), FullName: ptrTo("433 Eros (A898 PA)"), NEO: ptrTo(true)},
+			Orbit:    Orbit{Eccentricity: ptrTo(0.2228359407071628), Inclination: ptrTo(10.82)},
+			Physical: Physical{H: ptrTo(10.39)},
+		},
+		{Identity: Identity{SpkID: ptrTo(2000001), NEO: ptrTo(false)}},
+	}
+	var buf bytes.Buffer
+	if err := NewPayloadBodies(AllFields(), bodies).Encode(&buf); err != nil {
+		t.Fatal(err)
+	}
+	p, err := Decode(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := p.StrictBodies()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(bodies, got); diff != "" {
+		t.Errorf("round trip mismatch (-want +got):\n%s", diff)
+	}
+}
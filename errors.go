@@ -0,0 +1,77 @@
+package sbdb
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// APIError is returned when the SBDB API responds with a non-2xx status,
+// or when an API that reports errors in the response body, such as
+// Sentry or Scout, returns one. If the response body is a JPL error
+// payload, its code and message are parsed into Code and Message. Use
+// errors.As to inspect it:
+//
+//	var apiErr *sbdb.APIError
+//	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusBadRequest {
+//		// The query was rejected; see apiErr.Message.
+//	}
+type APIError struct {
+	StatusCode int    // HTTP status code
+	Code       string // API error code, if provided
+	Message    string // API error message, if provided
+	MoreInfo   string // Link to related documentation, if provided
+	URL        string // Request URL
+	Body       []byte // Raw response body
+}
+
+// Error implements the error interface.
+func (e *APIError) Error() string {
+	msg := e.Message
+	if msg == "" {
+		msg = strings.TrimSpace(string(e.Body))
+	}
+	if e.StatusCode >= 200 && e.StatusCode < 300 {
+		return "api error: " + msg
+	}
+	return fmt.Sprintf("unexpected status %d: %s", e.StatusCode, msg)
+}
+
+// newAPIError builds an APIError for a response to u, parsing body as a
+// JPL error payload when possible.
+func newAPIError(u string, status int, body []byte) *APIError {
+	e := &APIError{StatusCode: status, URL: u, Body: body}
+	var payload struct {
+		Code     any    `json:"code"`
+		Message  string `json:"message"`
+		MoreInfo string `json:"moreInfo"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return e
+	}
+	if payload.Code != nil {
+		e.Code = fmt.Sprint(payload.Code)
+	}
+	e.Message = payload.Message
+	e.MoreInfo = payload.MoreInfo
+	return e
+}
+
+// joinErrors renders a list of errors as one message, as used by the
+// Error methods of ValidationErrors and ConversionErrors.
+func joinErrors[E error](errs []E) string {
+	parts := make([]string, len(errs))
+	for i, e := range errs {
+		parts[i] = e.Error()
+	}
+	return strings.Join(parts, "; ")
+}
+
+// unwrapErrors converts a list of errors for use by an Unwrap method.
+func unwrapErrors[E error](errs []E) []error {
+	out := make([]error, len(errs))
+	for i, e := range errs {
+		out[i] = e
+	}
+	return out
+}
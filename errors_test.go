@@ -0,0 +1,68 @@
+package sbdb
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_newAPIError(t *testing.T) {
+	const u = "http://example.com/api?fields=spkid"
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		want    *APIError
+		wantMsg string
+	}{
+		{
+			name:   "JPL payload",
+			status: 400,
+			body:   `{"code":"400","message":"invalid field name in sb-cdata: foo","moreInfo":"https://ssd-api.jpl.nasa.gov/doc/sbdb_query.html"}`,
+			want: &APIError{
+				StatusCode: 400,
+				Code:       "400",
+				Message:    "invalid field name in sb-cdata: foo",
+				MoreInfo:   "https://ssd-api.jpl.nasa.gov/doc/sbdb_query.html",
+				URL:        u,
+				Body:       []byte(`{"code":"400","message":"invalid field name in sb-cdata: foo","moreInfo":"https://ssd-api.jpl.nasa.gov/doc/sbdb_query.html"}`),
+			},
+			wantMsg: "unexpected status 400: invalid field name in sb-cdata: foo",
+		},
+		{
+			name:   "numeric code",
+			status: 400,
+			body:   `{"code":400,"message":"bad"}`,
+			want: &APIError{
+				StatusCode: 400,
+				Code:       "400",
+				Message:    "bad",
+				URL:        u,
+				Body:       []byte(`{"code":400,"message":"bad"}`),
+			},
+			wantMsg: "unexpected status 400: bad",
+		},
+		{
+			name:   "plain text",
+			status: 503,
+			body:   "Service Unavailable\n",
+			want: &APIError{
+				StatusCode: 503,
+				URL:        u,
+				Body:       []byte("Service Unavailable\n"),
+			},
+			wantMsg: "unexpected status 503: Service Unavailable",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := newAPIError(u, tt.status, []byte(tt.body))
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("newAPIError() mismatch (-want +got):\n%s", diff)
+			}
+			if got.Error() != tt.wantMsg {
+				t.Errorf("Error() = %q, want %q", got.Error(), tt.wantMsg)
+			}
+		})
+	}
+}
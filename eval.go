@@ -0,0 +1,173 @@
+package sbdb
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Eval reports whether r satisfies e, following the semantics of the SBDB
+// filter documentation: values compare numerically when both sides are
+// numbers and as strings otherwise, RG bounds are inclusive, and every
+// comparison except ND is false for a NULL (nil) value. Boolean values
+// compare as "Y" or "N", the way the API reports them.
+//
+// An error is returned if e is malformed or references a field that is
+// absent from r. To evaluate a Body, use Eval(e, b.Record()).
+func Eval(e Expr, r Record) (bool, error) {
+	switch e := e.(type) {
+	case And:
+		for _, sub := range e {
+			ok, err := Eval(sub, r)
+			if err != nil || !ok {
+				return false, err
+			}
+		}
+		return true, nil
+	case Or:
+		for _, sub := range e {
+			ok, err := Eval(sub, r)
+			if err != nil || ok {
+				return ok, err
+			}
+		}
+		return false, nil
+	case ComparisonExpr:
+		return e.eval(r)
+	case nil:
+		return false, fmt.Errorf("nil expression")
+	default:
+		return false, fmt.Errorf("unsupported expression type %T", e)
+	}
+}
+
+// comparison is a parsed ComparisonExpr.
+type comparison struct {
+	field Field
+	op    operator
+	args  []string
+}
+
+var opByCode = func() map[string]operator {
+	m := make(map[string]operator, len(opCode))
+	for op, s := range opCode {
+		m[s] = op
+	}
+	return m
+}()
+
+// opArgs is the number of values each operator takes.
+var opArgs = map[operator]int{
+	OpEQ: 1, OpNE: 1, OpLT: 1, OpGT: 1, OpLE: 1, OpGE: 1,
+	OpRG: 2, OpRE: 1, OpDF: 0, OpND: 0,
+}
+
+// parse splits c into its field, operator and values. The value of an RE
+// expression is kept whole so patterns may contain '|'.
+func (c ComparisonExpr) parse() (comparison, error) {
+	parts := strings.SplitN(string(c), "|", 3)
+	if len(parts) < 2 {
+		return comparison{}, fmt.Errorf("invalid expression %q: missing operator", string(c))
+	}
+	op, ok := opByCode[parts[1]]
+	if !ok {
+		return comparison{}, fmt.Errorf("invalid expression %q: unknown operator %q", string(c), parts[1])
+	}
+	var args []string
+	if len(parts) == 3 {
+		args = []string{parts[2]}
+		if op != OpRE {
+			args = strings.Split(parts[2], "|")
+		}
+	}
+	if len(args) != opArgs[op] {
+		return comparison{}, fmt.Errorf("invalid expression %q: %v takes %d value(s), got %d", string(c), op, opArgs[op], len(args))
+	}
+	if parts[0] == "" {
+		return comparison{}, fmt.Errorf("invalid expression %q: missing field", string(c))
+	}
+	return comparison{field: Field(parts[0]), op: op, args: args}, nil
+}
+
+func (c ComparisonExpr) eval(r Record) (bool, error) {
+	cmp, err := c.parse()
+	if err != nil {
+		return false, err
+	}
+	v, ok := r[cmp.field]
+	if !ok {
+		return false, fmt.Errorf("field %q not present in record", cmp.field)
+	}
+	switch cmp.op {
+	case OpDF:
+		return v != nil, nil
+	case OpND:
+		return v == nil, nil
+	}
+	if v == nil {
+		return false, nil
+	}
+
+	text, _ := FormatValue(v)
+	switch cmp.op {
+	case OpRE:
+		re, err := compileRegexp(cmp.args[0])
+		if err != nil {
+			return false, err
+		}
+		return re.MatchString(text), nil
+	case OpRG:
+		return compareValues(text, cmp.args[0]) >= 0 && compareValues(text, cmp.args[1]) <= 0, nil
+	}
+
+	n := compareValues(text, cmp.args[0])
+	switch cmp.op {
+	case OpEQ:
+		return n == 0, nil
+	case OpNE:
+		return n != 0, nil
+	case OpLT:
+		return n < 0, nil
+	case OpGT:
+		return n > 0, nil
+	case OpLE:
+		return n <= 0, nil
+	case OpGE:
+		return n >= 0, nil
+	}
+	return false, fmt.Errorf("invalid expression %q: unsupported operator %v", string(c), cmp.op)
+}
+
+// compareValues compares a and b numerically if both parse as numbers,
+// and lexically otherwise. It returns -1, 0 or +1.
+func compareValues(a, b string) int {
+	fa, errA := strconv.ParseFloat(strings.TrimSpace(a), 64)
+	fb, errB := strconv.ParseFloat(strings.TrimSpace(b), 64)
+	if errA == nil && errB == nil {
+		switch {
+		case fa < fb:
+			return -1
+		case fa > fb:
+			return 1
+		default:
+			return 0
+		}
+	}
+	return strings.Compare(a, b)
+}
+
+var regexpCache sync.Map // map[string]*regexp.Regexp
+
+func compileRegexp(pattern string) (*regexp.Regexp, error) {
+	if re, ok := regexpCache.Load(pattern); ok {
+		return re.(*regexp.Regexp), nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid regular expression %q: %w", pattern, err)
+	}
+	regexpCache.Store(pattern, re)
+	return re, nil
+}
@@ -0,0 +1,91 @@
+package sbdb
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestEval(t *testing.T) {
+	r := Record{
+		Eccentricity:   json.Number("0.95"),
+		PerihelionDist: "1.1",
+		Class:          "APO",
+		FullName:       "433 Eros (A898 PA)",
+		NEO:            "Y",
+		PHA:            false,
+		Albedo:         nil,
+		Sats:           2,
+		H:              15.5,
+	}
+	tests := []struct {
+		name    string
+		e       Expr
+		want    bool
+		wantErr bool
+	}{
+		{name: "EQ string", e: EQ("class", "APO"), want: true},
+		{name: "EQ string mismatch", e: EQ("class", "AMO"), want: false},
+		{name: "EQ numeric", e: EQ("e", "0.950"), want: true},
+		{name: "EQ bool", e: EQ("neo", "Y"), want: true},
+		{name: "EQ bool value", e: EQ("pha", "N"), want: true},
+		{name: "NE", e: NE("class", "AMO"), want: true},
+		{name: "LT numeric", e: LT("q", "1.3"), want: true},
+		{name: "LT compares numbers not text", e: LT("sats", "10"), want: true},
+		{name: "GT", e: GT("e", "0.9"), want: true},
+		{name: "GT float64", e: GT("H", "15"), want: true},
+		{name: "LE equal", e: LE("q", "1.1"), want: true},
+		{name: "GE", e: GE("q", "1.2"), want: false},
+		{name: "RG inclusive", e: RG("q", "1.1", "1.3"), want: true},
+		{name: "RG outside", e: RG("e", "0.1", "0.5"), want: false},
+		{name: "RE", e: RE("full_name", "^433 (Eros|Ceres)"), want: true},
+		{name: "RE no match", e: RE("full_name", "^1 "), want: false},
+		{name: "DF", e: DF("e"), want: true},
+		{name: "DF nil", e: DF("albedo"), want: false},
+		{name: "ND nil", e: ND("albedo"), want: true},
+		{name: "comparison with nil", e: NE("albedo", "0.2"), want: false},
+		{
+			name: "nested",
+			e:    Or{And{GT("e", "0.9"), LT("q", "1.3")}, EQ("class", "AMO")},
+			want: true,
+		},
+		{
+			name: "nested false",
+			e:    And{Or{EQ("class", "AMO"), EQ("class", "ATE")}, DF("e")},
+			want: false,
+		},
+		{name: "empty And", e: And{}, want: true},
+		{name: "empty Or", e: Or{}, want: false},
+		{name: "unknown field", e: EQ("moid", "1"), wantErr: true},
+		{name: "unknown operator", e: ComparisonExpr("e|XX|1"), wantErr: true},
+		{name: "missing operator", e: ComparisonExpr("e"), wantErr: true},
+		{name: "missing value", e: ComparisonExpr("e|EQ"), wantErr: true},
+		{name: "RG missing bound", e: ComparisonExpr("e|RG|1"), wantErr: true},
+		{name: "bad regexp", e: RE("class", "("), wantErr: true},
+		{name: "nil", e: nil, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Eval(tt.e, r)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Eval() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Eval() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEval_Body(t *testing.T) {
+	b := Body{
+		Identity: Identity{NEO: ptrTo(true), Class: ptrTo("APO")},
+		Orbit:    Orbit{Eccentricity: ptrTo(0.95)},
+	}
+	got, err := Eval(And{EQ("neo", "Y"), GT("e", "0.9"), ND("albedo")}, b.Record())
+	if err != nil {
+		t.Fatalf("Eval() error = %v", err)
+	}
+	if !got {
+		t.Error("Eval() = false, want true")
+	}
+}
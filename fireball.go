@@ -0,0 +1,164 @@
+package sbdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"time"
+)
+
+// FireballEndpoint is the default base URL for the JPL Fireball API. It
+// can be overridden via Client.FireballEndpoint.
+const FireballEndpoint = "https://ssd-api.jpl.nasa.gov/fireball.api"
+
+// FireballSort selects the order of fireball results.
+// The zero value uses the API default, which sorts by date.
+type FireballSort uint
+
+const (
+	FireballSortDefault FireballSort = iota
+	FireballSortDate                 // Time of peak brightness
+	FireballSortEnergy               // Total radiated energy
+	FireballSortImpactE              // Calculated impact energy
+	FireballSortVel                  // Pre-impact velocity
+	FireballSortAlt                  // Altitude of peak brightness
+)
+
+var fireballSortNames = map[FireballSort]string{
+	FireballSortDefault: "", FireballSortDate: "date", FireballSortEnergy: "energy",
+	FireballSortImpactE: "impact-e", FireballSortVel: "vel", FireballSortAlt: "alt",
+}
+
+func (s FireballSort) String() string {
+	if n, ok := fireballSortNames[s]; ok {
+		return n
+	}
+	return fmt.Sprintf("Invalid FireballSort(%d)", s)
+}
+
+// FireballQuery defines the search parameters for the Fireball API,
+// documented at https://ssd-api.jpl.nasa.gov/doc/fireball.html. Zero
+// values leave the API defaults in place and return every reported event.
+type FireballQuery struct {
+	DateMin    time.Time // Earliest time of peak brightness
+	DateMax    time.Time // Latest time of peak brightness
+	EnergyMin  float64   // Minimum total radiated energy (10^10 J)
+	EnergyMax  float64   // Maximum total radiated energy (10^10 J)
+	ImpactEMin float64   // Minimum calculated impact energy (kt)
+	ImpactEMax float64   // Maximum calculated impact energy (kt)
+	AltMin     float64   // Minimum altitude of peak brightness (km)
+	AltMax     float64   // Maximum altitude of peak brightness (km)
+
+	ReqLoc     bool // Only events with a known location
+	ReqAlt     bool // Only events with a known altitude
+	ReqVel     bool // Only events with a known velocity
+	ReqVelComp bool // Only events with known velocity components; implies VelComp
+	// VelComp, when true, includes the velocity components in the
+	// results.
+	VelComp bool
+
+	Sort     FireballSort
+	SortDesc bool // Sort in descending order
+	Limit    uint
+}
+
+// Values converts q into URL query parameters.
+func (q FireballQuery) Values() (url.Values, error) {
+	if err := checkTimeRange("DateMin", q.DateMin, "DateMax", q.DateMax); err != nil {
+		return nil, err
+	}
+	if q.Sort > FireballSortAlt {
+		return nil, errors.New(q.Sort.String())
+	}
+
+	v := url.Values{}
+	p := params(v)
+	p.setTime("date-min", q.DateMin)
+	p.setTime("date-max", q.DateMax)
+	p.setFloat("energy-min", q.EnergyMin)
+	p.setFloat("energy-max", q.EnergyMax)
+	p.setFloat("impact-e-min", q.ImpactEMin)
+	p.setFloat("impact-e-max", q.ImpactEMax)
+	p.setFloat("alt-min", q.AltMin)
+	p.setFloat("alt-max", q.AltMax)
+	p.setBool("req-loc", q.ReqLoc)
+	p.setBool("req-alt", q.ReqAlt)
+	p.setBool("req-vel", q.ReqVel)
+	p.setBool("req-vel-comp", q.ReqVelComp)
+	p.setBool("vel-comp", q.VelComp || q.ReqVelComp)
+	p.setSort(q.Sort.String(), q.SortDesc)
+	p.setLimit(q.Limit)
+	return v, nil
+}
+
+// Fireball is a fireball or bolide event reported by US Government
+// sensors.
+type Fireball struct {
+	Time         time.Time // Time of peak brightness (UT)
+	Energy       *float64  // Total radiated energy (10^10 J)
+	ImpactEnergy *float64  // Calculated total impact energy (kt)
+	Lat          *float64  // Latitude of peak brightness (degrees, north positive)
+	Lon          *float64  // Longitude of peak brightness (degrees, east positive)
+	Alt          *float64  // Altitude of peak brightness (km)
+	Vel          *float64  // Pre-impact velocity (km/s)
+	VX           *float64  // Pre-impact velocity, Earth-centered X component (km/s)
+	VY           *float64  // Pre-impact velocity, Earth-centered Y component (km/s)
+	VZ           *float64  // Pre-impact velocity, Earth-centered Z component (km/s)
+}
+
+// fireball converts a record keyed by the Fireball API's field names.
+func (r Record) fireball() Fireball {
+	return Fireball{
+		Time:         deref(r.getTime("date")),
+		Energy:       r.getFloat("energy"),
+		ImpactEnergy: r.getFloat("impact-e"),
+		Lat:          r.signedCoord("lat", "lat-dir", "S"),
+		Lon:          r.signedCoord("lon", "lon-dir", "W"),
+		Alt:          r.getFloat("alt"),
+		Vel:          r.getFloat("vel"),
+		VX:           r.getFloat("vx"),
+		VY:           r.getFloat("vy"),
+		VZ:           r.getFloat("vz"),
+	}
+}
+
+// signedCoord returns the unsigned coordinate in field, negated when the
+// direction in dirField is neg.
+func (r Record) signedCoord(field, dirField Field, neg string) *float64 {
+	v := r.getFloat(field)
+	if v == nil {
+		return nil
+	}
+	if dir := r.getString(dirField); dir != nil && *dir == neg {
+		*v = -*v
+	}
+	return v
+}
+
+// FireballURL builds a URL for the Fireball API request represented by q.
+// If Client.FireballEndpoint is empty, the default FireballEndpoint is
+// used.
+func (c *Client) FireballURL(q FireballQuery) (*url.URL, error) {
+	return queryURL(c.FireballEndpoint, FireballEndpoint, q)
+}
+
+// Fireballs queries the Fireball API.
+func (c *Client) Fireballs(ctx context.Context, q FireballQuery) ([]Fireball, error) {
+	u, err := c.FireballURL(q)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.get(ctx, u)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	return DecodeFireballs(resp.Body)
+}
+
+// DecodeFireballs parses a Fireball API payload from r.
+func DecodeFireballs(r io.Reader) ([]Fireball, error) {
+	return decodeColumnarAs(r, Record.fireball)
+}
@@ -0,0 +1,139 @@
+package sbdb
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+const fireballPayload = `{"signature":{"source":"NASA/JPL Fireball Data API","version":"1.0"},"count":"2",
+"fields":["date","energy","impact-e","lat","lat-dir","lon","lon-dir","alt","vel","vx","vy","vz"],
+"data":[
+	["2013-02-15 03:20:33","3750","440","54.8","N","61.1","E","23.3","18.6","12.8","-13.3","-2.4"],
+	["2024-01-21 00:32:38","2.1","0.078","52.5","N","12.6","W",null,null,null,null,null]
+]}`
+
+func TestFireballQuery_Values(t *testing.T) {
+	tests := []struct {
+		name    string
+		q       FireballQuery
+		want    url.Values
+		wantErr bool
+	}{
+		{name: "defaults", q: FireballQuery{}, want: url.Values{}},
+		{
+			name: "full",
+			q: FireballQuery{
+				DateMin: time.Date(2010, 1, 1, 0, 0, 0, 0, time.UTC), DateMax: time.Date(2020, 6, 30, 12, 0, 0, 0, time.UTC),
+				EnergyMin: 0.5, EnergyMax: 100, ImpactEMin: 0.1, ImpactEMax: 500, AltMin: 10, AltMax: 60,
+				ReqLoc: true, ReqAlt: true, ReqVel: true, Sort: FireballSortImpactE, SortDesc: true, Limit: 5,
+			},
+			want: url.Values{
+				"date-min": {"2010-01-01T00:00:00"}, "date-max": {"2020-06-30T12:00:00"},
+				"energy-min": {"0.5"}, "energy-max": {"100"}, "impact-e-min": {"0.1"}, "impact-e-max": {"500"},
+				"alt-min": {"10"}, "alt-max": {"60"}, "req-loc": {"true"}, "req-alt": {"true"}, "req-vel": {"true"},
+				"sort": {"-impact-e"}, "limit": {"5"},
+			},
+		},
+		{name: "velocity components", q: FireballQuery{VelComp: true}, want: url.Values{"vel-comp": {"true"}}},
+		{
+			name: "require velocity components",
+			q:    FireballQuery{ReqVelComp: true},
+			want: url.Values{"req-vel-comp": {"true"}, "vel-comp": {"true"}},
+		},
+		{
+			name:    "dates reversed",
+			q:       FireballQuery{DateMin: time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC), DateMax: time.Date(2029, 1, 1, 0, 0, 0, 0, time.UTC)},
+			wantErr: true,
+		},
+		{name: "invalid sort", q: FireballQuery{Sort: 99}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.q.Values()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Values() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Values() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDecodeFireballs(t *testing.T) {
+	got, err := DecodeFireballs(strings.NewReader(fireballPayload))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Fireball{
+		{
+			Time: time.Date(2013, 2, 15, 3, 20, 33, 0, time.UTC), Energy: ptrTo(3750.0), ImpactEnergy: ptrTo(440.0),
+			Lat: ptrTo(54.8), Lon: ptrTo(61.1), Alt: ptrTo(23.3), Vel: ptrTo(18.6),
+			VX: ptrTo(12.8), VY: ptrTo(-13.3), VZ: ptrTo(-2.4),
+		},
+		{
+			Time: time.Date(2024, 1, 21, 0, 32, 38, 0, time.UTC), Energy: ptrTo(2.1), ImpactEnergy: ptrTo(0.078),
+			Lat: ptrTo(52.5), Lon: ptrTo(-12.6),
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("DecodeFireballs() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestRecord_signedCoord(t *testing.T) {
+	tests := []struct {
+		name string
+		r    Record
+		want *float64
+	}{
+		{"north", Record{"lat": "54.8", "lat-dir": "N"}, ptrTo(54.8)},
+		{"south", Record{"lat": "33.1", "lat-dir": "S"}, ptrTo(-33.1)},
+		{"no direction", Record{"lat": "12.5"}, ptrTo(12.5)},
+		{"direction without value", Record{"lat": nil, "lat-dir": "S"}, nil},
+		{"missing", Record{}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.r.signedCoord("lat", "lat-dir", "S")
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("signedCoord() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRecord_fireball_Directions(t *testing.T) {
+	got := Record{"lat": "10.5", "lat-dir": "S", "lon": "120.25", "lon-dir": "W"}.fireball()
+	if diff := cmp.Diff(ptrTo(-10.5), got.Lat); diff != "" {
+		t.Errorf("Lat mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(ptrTo(-120.25), got.Lon); diff != "" {
+		t.Errorf("Lon mismatch (-want +got):\n%s", diff)
+	}
+	got = Record{"lat": nil, "lat-dir": "N", "lon": nil, "lon-dir": "E"}.fireball()
+	if got.Lat != nil || got.Lon != nil {
+		t.Errorf("fireball() Lat, Lon = %v, %v, want nil", got.Lat, got.Lon)
+	}
+}
+
+func TestClient_Fireballs(t *testing.T) {
+	srv, query := apiServer(t, http.StatusOK, fireballPayload)
+	c := &Client{FireballEndpoint: srv.URL}
+	got, err := c.Fireballs(context.Background(), FireballQuery{ReqLoc: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 {
+		t.Errorf("Fireballs() returned %d fireballs, want 2", len(got))
+	}
+	if q := query.Get("req-loc"); q != "true" {
+		t.Errorf("req-loc = %q, want true", q)
+	}
+}
@@ -0,0 +1,169 @@
+package sbdb
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+)
+
+// Counts holds the number of bodies in the database by kind and group,
+// as reported by the API's info=count mode.
+type Counts struct {
+	Total     int // All bodies ("all")
+	Asteroids int // Asteroids ("ast")
+	Comets    int // Comets ("com")
+	NEOs      int // Near-Earth objects ("neo")
+	PHAs      int // Potentially hazardous asteroids ("pha")
+}
+
+// FieldDef describes an output field offered by the API, as reported by
+// its info=field mode.
+type FieldDef struct {
+	Name        string // Field name, as used in Filter.Fields
+	Title       string // Short human-readable title
+	Description string // Longer description, if provided
+	Units       string // Units, if provided
+	Group       string // Group the API lists the field under
+}
+
+// Count requests the API's info=count mode and returns the number of
+// bodies in the database. It returns an error if any count is missing or
+// is not an integer.
+func (c *Client) Count(ctx context.Context) (*Counts, error) {
+	var info struct {
+		Count map[string]json.Number `json:"count"`
+	}
+	if err := c.getInfo(ctx, "count", &info); err != nil {
+		return nil, err
+	}
+	if info.Count == nil {
+		return nil, fmt.Errorf("response has no count information")
+	}
+	counts := &Counts{}
+	for _, k := range []struct {
+		key string
+		n   *int
+	}{
+		{"all", &counts.Total},
+		{"ast", &counts.Asteroids},
+		{"com", &counts.Comets},
+		{"neo", &counts.NEOs},
+		{"pha", &counts.PHAs},
+	} {
+		v, ok := info.Count[k.key]
+		if !ok {
+			return nil, fmt.Errorf("response has no %q count", k.key)
+		}
+		n, err := v.Int64()
+		if err != nil {
+			return nil, fmt.Errorf("count %q: %w", k.key, err)
+		}
+		*k.n = int(n)
+	}
+	return counts, nil
+}
+
+// FieldDefs requests the API's info=field mode and returns the output
+// fields the server offers, sorted by group and name.
+func (c *Client) FieldDefs(ctx context.Context) ([]FieldDef, error) {
+	var info struct {
+		Field json.RawMessage `json:"field"`
+	}
+	if err := c.getInfo(ctx, "field", &info); err != nil {
+		return nil, err
+	}
+	if len(info.Field) == 0 {
+		return nil, fmt.Errorf("response has no field information")
+	}
+	defs, err := parseFieldDefs(info.Field)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(defs, func(i, j int) bool {
+		if defs[i].Group != defs[j].Group {
+			return defs[i].Group < defs[j].Group
+		}
+		return defs[i].Name < defs[j].Name
+	})
+	return defs, nil
+}
+
+// CheckFields compares the known Field constants against defs, as
+// returned by FieldDefs. It returns the known fields the server does not
+// offer and the server fields this package has no constant for.
+func CheckFields(defs []FieldDef) (missing []Field, extra []string) {
+	offered := make(map[string]bool, len(defs))
+	for _, d := range defs {
+		offered[d.Name] = true
+		if _, ok := Field(d.Name).Info(); !ok {
+			extra = append(extra, d.Name)
+		}
+	}
+	for _, f := range AllFields() {
+		if !offered[f.String()] {
+			missing = append(missing, f)
+		}
+	}
+	return missing, extra
+}
+
+// getInfo issues an info-mode request and decodes the "info" member of
+// the response into v.
+func (c *Client) getInfo(ctx context.Context, mode string, v any) error {
+	u, err := c.endpointURL()
+	if err != nil {
+		return err
+	}
+	u.RawQuery = url.Values{"info": []string{mode}}.Encode()
+
+	resp, err := c.get(ctx, u)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	var out struct {
+		Info json.RawMessage `json:"info"`
+	}
+	dec := json.NewDecoder(resp.Body)
+	dec.UseNumber()
+	if err := dec.Decode(&out); err != nil {
+		return fmt.Errorf("decode failed: %w", err)
+	}
+	if len(out.Info) == 0 {
+		return fmt.Errorf("response has no info member")
+	}
+	if err := json.Unmarshal(out.Info, v); err != nil {
+		return fmt.Errorf("decode failed: %w", err)
+	}
+	return nil
+}
+
+// fieldDefJSON is a field definition as listed by the info=field mode.
+type fieldDefJSON struct {
+	Name  string  `json:"name"`
+	Title string  `json:"title"`
+	Desc  string  `json:"desc"`
+	Units *string `json:"units"`
+}
+
+// parseFieldDefs decodes the info=field listing, an object mapping group
+// names to lists of field definitions.
+func parseFieldDefs(raw json.RawMessage) ([]FieldDef, error) {
+	var groups map[string][]fieldDefJSON
+	if err := json.Unmarshal(raw, &groups); err != nil {
+		return nil, fmt.Errorf("decode failed: %w", err)
+	}
+	var defs []FieldDef
+	for g, list := range groups {
+		for i, d := range list {
+			if d.Name == "" {
+				return nil, fmt.Errorf("field %d of group %q has no name", i, g)
+			}
+			defs = append(defs, FieldDef{Name: d.Name, Title: d.Title, Description: d.Desc, Units: deref(d.Units), Group: g})
+		}
+	}
+	return defs, nil
+}
@@ -0,0 +1,124 @@
+package sbdb
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// infoFieldPayload is an excerpt of an info=field response.
+const infoFieldPayload = `{"signature":{"source":"NASA/JPL Small-Body Database (SBDB) Query API","version":"1.0"},"info":{"field":{
+	"object":[
+		{"name":"spkid","title":"SPK-ID","desc":"object primary SPK-ID","units":null},
+		{"name":"full_name","title":"full name","desc":"object full name/designation","units":null}
+	],
+	"orbit":[
+		{"name":"e","title":"e","desc":"eccentricity","units":null},
+		{"name":"a","title":"a","desc":"semi-major axis","units":"au"}
+	]
+}}}`
+
+func TestClient_Count(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		want    *Counts
+		wantErr bool
+	}{
+		{
+			name: "ok",
+			body: `{"signature":{"version":"1.0"},"info":{"count":{"all":1404000,"ast":1400000,"com":4000,"neo":35000,"pha":2400}}}`,
+			want: &Counts{Total: 1404000, Asteroids: 1400000, Comets: 4000, NEOs: 35000, PHAs: 2400},
+		},
+		{name: "no info", body: `{"count":1}`, wantErr: true},
+		{name: "no count", body: `{"info":{"field":[]}}`, wantErr: true},
+		{name: "missing key", body: `{"info":{"count":{"all":10,"ast":8,"com":2}}}`, wantErr: true},
+		{name: "unexpected keys", body: `{"info":{"count":{"asteroids":8,"comets":2}}}`, wantErr: true},
+		{name: "bad count", body: `{"info":{"count":{"all":1.5,"ast":1,"com":0,"neo":0,"pha":0}}}`, wantErr: true},
+		{name: "invalid json", body: `{`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, query := apiServer(t, http.StatusOK, tt.body)
+			c := &Client{Endpoint: srv.URL}
+			got, err := c.Count(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Count() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if info := query.Get("info"); info != "count" {
+				t.Errorf("info = %q, want count", info)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Count() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestClient_FieldDefs(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		want    []FieldDef
+		wantErr bool
+	}{
+		{
+			name: "grouped",
+			body: infoFieldPayload,
+			want: []FieldDef{
+				{Name: "full_name", Title: "full name", Description: "object full name/designation", Group: "object"},
+				{Name: "spkid", Title: "SPK-ID", Description: "object primary SPK-ID", Group: "object"},
+				{Name: "a", Title: "a", Description: "semi-major axis", Units: "au", Group: "orbit"},
+				{Name: "e", Title: "e", Description: "eccentricity", Group: "orbit"},
+			},
+		},
+		{name: "flat", body: `{"info":{"field":[{"name":"spkid"}]}}`, wantErr: true},
+		{name: "no name", body: `{"info":{"field":{"object":[{"title":"SPK-ID"}]}}}`, wantErr: true},
+		{name: "no field", body: `{"info":{"count":{}}}`, wantErr: true},
+		{name: "bad format", body: `{"info":{"field":"spkid"}}`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, query := apiServer(t, http.StatusOK, tt.body)
+			c := &Client{Endpoint: srv.URL}
+			got, err := c.FieldDefs(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("FieldDefs() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if info := query.Get("info"); info != "field" {
+				t.Errorf("info = %q, want field", info)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("FieldDefs() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	t.Run("http error", func(t *testing.T) {
+		srv, _ := apiServer(t, http.StatusBadRequest, `{"code":"400","message":"invalid info mode"}`)
+		c := &Client{Endpoint: srv.URL}
+		if _, err := c.FieldDefs(context.Background()); err == nil {
+			t.Fatal("expected error for http 400")
+		}
+	})
+}
+
+func TestCheckFields(t *testing.T) {
+	var defs []FieldDef
+	for _, f := range AllFields() {
+		if f != Albedo {
+			defs = append(defs, FieldDef{Name: f.String()})
+		}
+	}
+	defs = append(defs, FieldDef{Name: "new_field"})
+
+	missing, extra := CheckFields(defs)
+	if diff := cmp.Diff([]Field{Albedo}, missing); diff != "" {
+		t.Errorf("missing mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"new_field"}, extra); diff != "" {
+		t.Errorf("extra mismatch (-want +got):\n%s", diff)
+	}
+}
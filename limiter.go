@@ -0,0 +1,120 @@
+package sbdb
+
+import (
+	"context"
+	"io"
+	"sync"
+	"time"
+)
+
+// Limiter throttles requests with a token bucket and caps the number of
+// requests in flight. A Limiter is safe for concurrent use; share one
+// Client, or one Limiter across several Clients, to keep every worker
+// within the same budget.
+type Limiter struct {
+	mu     sync.Mutex
+	rate   float64 // tokens added per second; <= 0 disables the bucket
+	burst  float64
+	tokens float64
+	last   time.Time
+
+	slots chan struct{} // nil disables the in-flight cap
+}
+
+// NewLimiter returns a Limiter that allows rps requests per second with
+// bursts of up to burst requests, and at most maxInFlight requests at
+// once. A non-positive rps disables rate limiting and a non-positive
+// maxInFlight disables the concurrency cap. Burst values below 1 are
+// treated as 1.
+func NewLimiter(rps float64, burst, maxInFlight int) *Limiter {
+	if burst < 1 {
+		burst = 1
+	}
+	l := &Limiter{rate: rps, burst: float64(burst), tokens: float64(burst)}
+	if maxInFlight > 0 {
+		l.slots = make(chan struct{}, maxInFlight)
+	}
+	return l
+}
+
+// Wait blocks until a request may proceed or ctx is done. On success the
+// caller must call release once the request has completed. A nil Limiter
+// never blocks.
+func (l *Limiter) Wait(ctx context.Context) (release func(), err error) {
+	if l == nil {
+		return func() {}, nil
+	}
+	if l.slots != nil {
+		select {
+		case l.slots <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	release = l.releaseFunc()
+	if err := l.take(ctx); err != nil {
+		release()
+		return nil, err
+	}
+	return release, nil
+}
+
+func (l *Limiter) releaseFunc() func() {
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			if l.slots != nil {
+				<-l.slots
+			}
+		})
+	}
+}
+
+// take reserves a token from the bucket, sleeping until it is available.
+// The reservation is returned if ctx is done before then.
+func (l *Limiter) take(ctx context.Context) error {
+	if l.rate <= 0 {
+		return nil
+	}
+	l.mu.Lock()
+	now := time.Now()
+	if !l.last.IsZero() {
+		l.tokens += now.Sub(l.last).Seconds() * l.rate
+		if l.tokens > l.burst {
+			l.tokens = l.burst
+		}
+	}
+	l.last = now
+	l.tokens--
+	var wait time.Duration
+	if l.tokens < 0 {
+		wait = time.Duration(-l.tokens / l.rate * float64(time.Second))
+	}
+	l.mu.Unlock()
+
+	if wait <= 0 {
+		return nil
+	}
+	t := time.NewTimer(wait)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		l.mu.Lock()
+		l.tokens++
+		l.mu.Unlock()
+		return ctx.Err()
+	}
+}
+
+// releaseOnClose releases a Limiter slot when the response body is closed.
+type releaseOnClose struct {
+	io.ReadCloser
+	release func()
+}
+
+func (r *releaseOnClose) Close() error {
+	defer r.release()
+	return r.ReadCloser.Close()
+}
@@ -0,0 +1,129 @@
+package sbdb
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestLimiter_Wait(t *testing.T) {
+	t.Run("nil", func(t *testing.T) {
+		var l *Limiter
+		release, err := l.Wait(context.Background())
+		if err != nil {
+			t.Fatalf("Wait() error = %v", err)
+		}
+		release()
+	})
+
+	t.Run("rate", func(t *testing.T) {
+		l := NewLimiter(100, 1, 0)
+		start := time.Now()
+		for i := 0; i < 4; i++ {
+			release, err := l.Wait(context.Background())
+			if err != nil {
+				t.Fatalf("Wait() error = %v", err)
+			}
+			release()
+		}
+		// The first token is available immediately; the remaining three
+		// arrive at 10ms intervals.
+		if elapsed := time.Since(start); elapsed < 25*time.Millisecond {
+			t.Errorf("4 waits took %v, want about 30ms", elapsed)
+		}
+	})
+
+	t.Run("burst", func(t *testing.T) {
+		l := NewLimiter(1, 3, 0)
+		start := time.Now()
+		for i := 0; i < 3; i++ {
+			release, err := l.Wait(context.Background())
+			if err != nil {
+				t.Fatalf("Wait() error = %v", err)
+			}
+			release()
+		}
+		if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+			t.Errorf("burst of 3 took %v, want no wait", elapsed)
+		}
+	})
+
+	t.Run("max in flight", func(t *testing.T) {
+		l := NewLimiter(0, 0, 2)
+		var inFlight, peak int32
+		var wg sync.WaitGroup
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				release, err := l.Wait(context.Background())
+				if err != nil {
+					t.Errorf("Wait() error = %v", err)
+					return
+				}
+				defer release()
+				n := atomic.AddInt32(&inFlight, 1)
+				for {
+					p := atomic.LoadInt32(&peak)
+					if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+						break
+					}
+				}
+				time.Sleep(5 * time.Millisecond)
+				atomic.AddInt32(&inFlight, -1)
+			}()
+		}
+		wg.Wait()
+		if peak > 2 {
+			t.Errorf("peak in flight = %d, want <= 2", peak)
+		}
+	})
+
+	t.Run("context canceled", func(t *testing.T) {
+		l := NewLimiter(0, 0, 1)
+		release, err := l.Wait(context.Background())
+		if err != nil {
+			t.Fatalf("Wait() error = %v", err)
+		}
+		defer release()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		if _, err := l.Wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("Wait() error = %v, want context.DeadlineExceeded", err)
+		}
+	})
+}
+
+func TestClient_Get_Limiter(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	c := &Client{Endpoint: srv.URL, Limiter: NewLimiter(0, 0, 1)}
+	f := Filter{Fields: NewFieldSet(SpkID), Limit: 1}
+	resp, err := c.Get(context.Background(), f)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+
+	// The slot is held until the response body is closed.
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := c.Get(ctx, f); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Get() error = %v, want context.DeadlineExceeded", err)
+	}
+
+	resp.Body.Close()
+	resp, err = c.Get(context.Background(), f)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	resp.Body.Close()
+}
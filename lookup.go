@@ -0,0 +1,383 @@
+package sbdb
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// ErrNotFound is returned by Client.Lookup when no object matches the
+// query.
+var ErrNotFound = errors.New("object not found")
+
+// ObjectQuery selects a single body from the SBDB object API and the
+// optional data to return with it. Exactly one of Search, SpkID and
+// Designation must be set.
+type ObjectQuery struct {
+	Search      string // Designation, name or SPK-ID to search for
+	SpkID       int    // Exact SPK-ID
+	Designation string // Exact primary designation
+
+	Covariance      bool // Include the orbit covariance matrix
+	PhysicalParams  bool // Include physical parameters with references
+	CloseApproaches bool // Include close-approach data
+	Discovery       bool // Include discovery circumstances
+	FullPrecision   bool // Return numbers at full precision
+}
+
+// Values converts q to url.Values for the object API.
+func (q ObjectQuery) Values() (url.Values, error) {
+	v := url.Values{}
+	var n int
+	if q.Search != "" {
+		v.Set("sstr", q.Search)
+		n++
+	}
+	if q.SpkID != 0 {
+		v.Set("spk", strconv.Itoa(q.SpkID))
+		n++
+	}
+	if q.Designation != "" {
+		v.Set("des", q.Designation)
+		n++
+	}
+	if n != 1 {
+		return nil, fmt.Errorf("must provide exactly one of Search, SpkID or Designation")
+	}
+	if q.Covariance {
+		v.Set("cov", "mat")
+	}
+	if q.PhysicalParams {
+		v.Set("phys-par", strconv.FormatBool(q.PhysicalParams))
+	}
+	if q.CloseApproaches {
+		v.Set("ca-data", strconv.FormatBool(q.CloseApproaches))
+	}
+	if q.Discovery {
+		v.Set("discovery", strconv.FormatBool(q.Discovery))
+	}
+	if q.FullPrecision {
+		v.Set("full-prec", strconv.FormatBool(q.FullPrecision))
+	}
+	return v, nil
+}
+
+// Object is a single body returned by the SBDB object API.
+type Object struct {
+	Signature Signature
+	// Body holds the object's identity, orbital elements and their
+	// uncertainties, orbit solution, non-gravitational parameters and
+	// physical parameter values, converted with the same rules as
+	// Payload.Bodies.
+	Body            Body
+	ClassName       string          // Orbit class name, e.g. "Amor"
+	Elements        []Element       // Orbital elements as reported
+	PhysicalParams  []PhysicalParam // Physical parameters, if requested
+	Covariance      *Covariance     // Orbit covariance, if requested
+	Discovery       *Discovery      // Discovery circumstances, if requested
+	CloseApproaches []CloseApproach // Close approaches, if requested
+}
+
+// Element is an orbital element as reported by the object API.
+type Element struct {
+	Name  string // Field name, e.g. "e"
+	Title string
+	Label string
+	Units string
+	Value *float64
+	Sigma *float64 // 1-sigma uncertainty
+}
+
+// PhysicalParam is a physical parameter with its source. Values are kept
+// as text because some parameters, such as spectral types, are not
+// numeric; numeric values are also decoded into Object.Body.Physical.
+type PhysicalParam struct {
+	Name        string // Field name, e.g. "H"
+	Title       string
+	Description string
+	Units       string
+	Value       string
+	Sigma       string
+	Notes       string
+	Ref         string // Reference for the value
+}
+
+// Covariance is the covariance matrix of an orbit solution.
+type Covariance struct {
+	Epoch  *float64 // Epoch of the covariance (JD)
+	Labels []string // Labels of the matrix rows and columns
+	Data   [][]float64
+}
+
+// Discovery describes the discovery circumstances of a body.
+type Discovery struct {
+	Date     string // Discovery date as reported, e.g. "1898-Aug-13"
+	Location string
+	Site     string
+	Name     string // Name of the discoverer(s)
+	Text     string // Discovery summary
+	Citation string // Naming citation
+	Ref      string
+}
+
+// Match is one candidate of an ambiguous lookup.
+type Match struct {
+	Designation string `json:"pdes"`
+	Name        string `json:"name"`
+}
+
+// AmbiguousError is returned by Client.Lookup when a search matches more
+// than one object. Matches lists the candidates; look one of them up by
+// Designation to resolve it.
+type AmbiguousError struct {
+	Search  string
+	Message string // API message, if provided
+	Matches []Match
+}
+
+// Error implements the error interface.
+func (e *AmbiguousError) Error() string {
+	return fmt.Sprintf("search %q matched %d objects", e.Search, len(e.Matches))
+}
+
+// LookupURL builds a URL for the object API request represented by q. If
+// Client.LookupEndpoint is empty, the default LookupEndpoint is used.
+func (c *Client) LookupURL(q ObjectQuery) (*url.URL, error) {
+	return queryURL(c.LookupEndpoint, LookupEndpoint, q)
+}
+
+// Lookup fetches a single body from the SBDB object API. If the query
+// matches several objects it returns an *AmbiguousError; if it matches
+// none it returns an error wrapping ErrNotFound.
+func (c *Client) Lookup(ctx context.Context, q ObjectQuery) (*Object, error) {
+	u, err := c.LookupURL(q)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.get(ctx, u)
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		switch apiErr.StatusCode {
+		case http.StatusMultipleChoices:
+			if amb := ambiguous(q, apiErr.Body); amb != nil {
+				return nil, amb
+			}
+		case http.StatusNotFound:
+			return nil, fmt.Errorf("%w: %s", ErrNotFound, apiErr.Message)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var raw objectResponse
+	dec := json.NewDecoder(resp.Body)
+	dec.UseNumber()
+	if err := dec.Decode(&raw); err != nil {
+		return nil, fmt.Errorf("decode failed: %w", err)
+	}
+	if len(raw.List) > 0 {
+		return nil, &AmbiguousError{Search: q.search(), Message: raw.Message, Matches: raw.List}
+	}
+	if raw.Object == nil {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, raw.Message)
+	}
+	return raw.object()
+}
+
+// search returns the identifier q looks up, for error messages.
+func (q ObjectQuery) search() string {
+	switch {
+	case q.Search != "":
+		return q.Search
+	case q.Designation != "":
+		return q.Designation
+	}
+	return strconv.Itoa(q.SpkID)
+}
+
+func ambiguous(q ObjectQuery, body []byte) *AmbiguousError {
+	var raw struct {
+		Message string  `json:"message"`
+		List    []Match `json:"list"`
+	}
+	if err := json.Unmarshal(body, &raw); err != nil || len(raw.List) == 0 {
+		return nil
+	}
+	return &AmbiguousError{Search: q.search(), Message: raw.Message, Matches: raw.List}
+}
+
+// objectResponse mirrors the JSON layout of an object API response.
+type objectResponse struct {
+	Signature  Signature        `json:"signature"`
+	Message    string           `json:"message"`
+	List       []Match          `json:"list"`
+	Object     map[string]any   `json:"object"`
+	Orbit      json.RawMessage  `json:"orbit"`
+	PhysPar    []map[string]any `json:"phys_par"`
+	Discovery  map[string]any   `json:"discovery"`
+	CAData     []map[string]any `json:"ca_data"`
+	Covariance *covarianceJSON  `json:"covariance"`
+}
+
+type orbitJSON struct {
+	Elements   []map[string]any `json:"elements"`
+	ModelPars  []map[string]any `json:"model_pars"`
+	Covariance *covarianceJSON  `json:"covariance"`
+}
+
+type covarianceJSON struct {
+	Epoch  any      `json:"epoch"`
+	Labels []string `json:"labels"`
+	Data   [][]any  `json:"data"`
+}
+
+func (raw *objectResponse) object() (*Object, error) {
+	o := &Object{Signature: raw.Signature}
+	r := make(Record)
+	for k, v := range raw.Object {
+		switch k {
+		case "fullname":
+			r[FullName] = v
+		case "des":
+			r[PDes] = v
+		case "orbit_class":
+			if m, ok := v.(map[string]any); ok {
+				r[Class] = m["code"]
+				o.ClassName = text(m["name"])
+			}
+		default:
+			setKnown(r, Field(k), v)
+		}
+	}
+
+	var orbit orbitJSON
+	if len(raw.Orbit) > 0 && string(raw.Orbit) != "null" {
+		var scalars map[string]any
+		if err := decodeNumbers(raw.Orbit, &scalars); err != nil {
+			return nil, err
+		}
+		for k, v := range scalars {
+			setKnown(r, Field(k), v)
+		}
+		if err := decodeNumbers(raw.Orbit, &orbit); err != nil {
+			return nil, err
+		}
+	}
+	for _, e := range orbit.Elements {
+		name := text(e["name"])
+		setKnown(r, Field(name), e["value"])
+		setKnown(r, Field("sigma_"+name), e["sigma"])
+		o.Elements = append(o.Elements, Element{
+			Name:  name,
+			Title: text(e["title"]),
+			Label: text(e["label"]),
+			Units: text(e["units"]),
+			Value: Record{"value": e["value"]}.getFloat("value"),
+			Sigma: Record{"sigma": e["sigma"]}.getFloat("sigma"),
+		})
+	}
+	for _, p := range orbit.ModelPars {
+		name := text(p["name"])
+		setKnown(r, Field(name), p["value"])
+		setKnown(r, Field(name+"_sigma"), p["sigma"])
+	}
+	for _, p := range raw.PhysPar {
+		name := text(p["name"])
+		setKnown(r, Field(name), p["value"])
+		setKnown(r, Field(name+"_sigma"), p["sigma"])
+		o.PhysicalParams = append(o.PhysicalParams, PhysicalParam{
+			Name:        name,
+			Title:       text(p["title"]),
+			Description: text(p["desc"]),
+			Units:       text(p["units"]),
+			Value:       text(p["value"]),
+			Sigma:       text(p["sigma"]),
+			Notes:       text(p["notes"]),
+			Ref:         text(p["ref"]),
+		})
+	}
+	o.Body = r.body()
+
+	cov := raw.Covariance
+	if cov == nil {
+		cov = orbit.Covariance
+	}
+	if cov != nil {
+		c, err := cov.covariance()
+		if err != nil {
+			return nil, err
+		}
+		o.Covariance = c
+	}
+	if d := raw.Discovery; d != nil {
+		o.Discovery = &Discovery{
+			Date:     text(d["date"]),
+			Location: text(d["location"]),
+			Site:     text(d["site"]),
+			Name:     text(d["name"]),
+			Text:     text(d["discovery"]),
+			Citation: text(d["citation"]),
+			Ref:      text(d["ref"]),
+		}
+	}
+	for _, ca := range raw.CAData {
+		o.CloseApproaches = append(o.CloseApproaches, recordOf(ca).closeApproach())
+	}
+	return o, nil
+}
+
+func (c *covarianceJSON) covariance() (*Covariance, error) {
+	out := &Covariance{
+		Epoch:  Record{"epoch": c.Epoch}.getFloat("epoch"),
+		Labels: c.Labels,
+		Data:   make([][]float64, len(c.Data)),
+	}
+	for i, row := range c.Data {
+		out.Data[i] = make([]float64, len(row))
+		for j, v := range row {
+			f := Record{"data": v}.getFloat("data")
+			if f == nil {
+				return nil, fmt.Errorf("covariance element [%d][%d] is not a number: %v", i, j, v)
+			}
+			out.Data[i][j] = *f
+		}
+	}
+	return out, nil
+}
+
+// setKnown stores v in r if f is a registered field and v is not null.
+func setKnown(r Record, f Field, v any) {
+	if v == nil {
+		return
+	}
+	if _, ok := f.Info(); ok {
+		r[f] = v
+	}
+}
+
+// text renders a JSON value as a string, with null as "".
+func text(v any) string {
+	if v == nil {
+		return ""
+	}
+	if s, ok := v.(string); ok {
+		return s
+	}
+	return fmt.Sprint(v)
+}
+
+func decodeNumbers(data []byte, v any) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(v); err != nil {
+		return fmt.Errorf("decode failed: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,203 @@
+package sbdb
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+const erosObject = `{
+"signature":{"source":"NASA/JPL Small-Body Database (SBDB) API","version":"1.3"},
+"object":{"neo":true,"orbit_class":{"name":"Amor","code":"AMO"},"pha":false,"spkid":"2000433","kind":"an",
+	"orbit_id":"659","fullname":"433 Eros (A898 PA)","des":"433","prefix":null},
+"orbit":{"source":"JPL","moid_jup":"2.09","t_jup":"4.582","condition_code":"0","rms":".28","orbit_id":"659",
+	"producer":"Otto Matic","first_obs":"1893-10-29","soln_date":"2021-05-24 17:55:05","two_body":null,
+	"epoch":"2459600.5","equinox":"J2000","data_arc":"46455","n_obs_used":9130,"moid":".149","pe_used":"DE441",
+	"elements":[
+		{"value":".2228","sigma":"2.3e-9","name":"e","title":"eccentricity","label":"e","units":null},
+		{"value":"1.458","sigma":"2.1e-10","name":"a","title":"semi-major axis","label":"a","units":"au"}
+	],
+	"model_pars":[{"name":"A2","value":"1.1e-14","sigma":"2e-15","desc":"non-grav. transverse accel."}],
+	"covariance":{"epoch":"2459600.5","labels":["e","a"],"data":[["1e-18","2e-19"],["2e-19","3e-20"]]}
+},
+"phys_par":[
+	{"name":"H","value":"10.4","sigma":null,"title":"absolute magnitude","desc":"absolute magnitude","units":null,"ref":"MPO","notes":"autocmod"},
+	{"name":"diameter","value":"16.84","sigma":".06","title":"diameter","desc":"effective body diameter","units":"km","ref":"Thomas 2002","notes":null},
+	{"name":"spec_B","value":"S","sigma":null,"title":"SMASSII spectral type","desc":"","units":null,"ref":"Bus","notes":null}
+],
+"discovery":{"date":"1898-Aug-13","location":"Berlin","site":"Berlin","name":"G. Witt","discovery":"Discovered 1898 Aug. 13 by G. Witt at Berlin.","citation":"Named for the Greek god of love.","ref":"MPC"},
+"ca_data":[{"cd":"1900-Dec-27 01:29","jd":"2415380.562","dist":".3149","dist_min":".3149","dist_max":".3149","v_rel":"5.75","v_inf":"5.74","t_sigma_f":"< 00:01","body":"Earth","orbit_ref":"659"}]
+}`
+
+// apiServer starts a test server that answers every request with status
+// and body. The returned Values hold the query of the latest request.
+func apiServer(t *testing.T, status int, body string) (*httptest.Server, *url.Values) {
+	t.Helper()
+	return apiServerFunc(t, func(url.Values) (int, string) { return status, body })
+}
+
+// apiServerFunc is apiServer with each response chosen by respond from
+// the request's query.
+func apiServerFunc(t *testing.T, respond func(q url.Values) (int, string)) (*httptest.Server, *url.Values) {
+	t.Helper()
+	var got url.Values
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.URL.Query()
+		status, body := respond(got)
+		w.WriteHeader(status)
+		io.WriteString(w, body)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &got
+}
+
+func TestObjectQuery_Values(t *testing.T) {
+	tests := []struct {
+		name    string
+		q       ObjectQuery
+		want    url.Values
+		wantErr bool
+	}{
+		{name: "search", q: ObjectQuery{Search: "Eros"}, want: url.Values{"sstr": {"Eros"}}},
+		{name: "spkid", q: ObjectQuery{SpkID: 2000433, Covariance: true}, want: url.Values{"spk": {"2000433"}, "cov": {"mat"}}},
+		{
+			name: "designation with options",
+			q:    ObjectQuery{Designation: "433", PhysicalParams: true, CloseApproaches: true, Discovery: true, FullPrecision: true},
+			want: url.Values{"des": {"433"}, "phys-par": {"true"}, "ca-data": {"true"}, "discovery": {"true"}, "full-prec": {"true"}},
+		},
+		{name: "none", q: ObjectQuery{}, wantErr: true},
+		{name: "two", q: ObjectQuery{Search: "Eros", SpkID: 1}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.q.Values()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Values() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Values() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestClient_Lookup(t *testing.T) {
+	srv, query := apiServer(t, http.StatusOK, erosObject)
+	c := &Client{LookupEndpoint: srv.URL}
+	got, err := c.Lookup(context.Background(), ObjectQuery{Search: "Eros", PhysicalParams: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if q := query.Get("sstr"); q != "Eros" {
+		t.Errorf("sstr = %q, want Eros", q)
+	}
+	want := &Object{
+		Signature: Signature{Source: "NASA/JPL Small-Body Database (SBDB) API", Version: "1.3"},
+		Body: Body{
+			Identity: Identity{
+				SpkID: ptrTo(2000433), FullName: ptrTo("433 Eros (A898 PA)"), Kind: ptrTo("an"), PDES: ptrTo("433"),
+				Class: ptrTo("AMO"), NEO: ptrTo(true), PHA: ptrTo(false), TJupiter: ptrTo(4.582), MOID: ptrTo(0.149),
+				MOIDJupiter: ptrTo(2.09),
+			},
+			Orbit: Orbit{
+				OrbitID: ptrTo("659"), Epoch: ptrTo(2459600.5), Equinox: ptrTo("J2000"),
+				Eccentricity: ptrTo(0.2228), SemimajorAxis: ptrTo(1.458),
+			},
+			Uncertainty: Uncertainty{SigmaEcc: ptrTo(2.3e-9), SigmaA: ptrTo(2.1e-10)},
+			Solution: Solution{
+				Source: ptrTo("JPL"), SolutionDate: ptrTo("2021-05-24 17:55:05"), Producer: ptrTo("Otto Matic"),
+				DataArc: ptrTo(46455), FirstObs: ptrTo("1893-10-29"), ObsUsed: ptrTo(9130),
+			},
+			Quality:  Quality{PEUsed: ptrTo("DE441"), ConditionCode: ptrTo(0), RMS: ptrTo(0.28)},
+			NonGrav:  NonGrav{A2: ptrTo(1.1e-14), A2Sigma: ptrTo(2e-15)},
+			Physical: Physical{H: ptrTo(10.4), Diameter: ptrTo(16.84), DiameterSigma: ptrTo(0.06), SpecB: ptrTo("S")},
+		},
+		ClassName: "Amor",
+		Elements: []Element{
+			{Name: "e", Title: "eccentricity", Label: "e", Value: ptrTo(0.2228), Sigma: ptrTo(2.3e-9)},
+			{Name: "a", Title: "semi-major axis", Label: "a", Units: "au", Value: ptrTo(1.458), Sigma: ptrTo(2.1e-10)},
+		},
+		PhysicalParams: []PhysicalParam{
+			{Name: "H", Title: "absolute magnitude", Description: "absolute magnitude", Value: "10.4", Notes: "autocmod", Ref: "MPO"},
+			{Name: "diameter", Title: "diameter", Description: "effective body diameter", Units: "km", Value: "16.84", Sigma: ".06", Ref: "Thomas 2002"},
+			{Name: "spec_B", Title: "SMASSII spectral type", Value: "S", Ref: "Bus"},
+		},
+		Covariance: &Covariance{Epoch: ptrTo(2459600.5), Labels: []string{"e", "a"}, Data: [][]float64{{1e-18, 2e-19}, {2e-19, 3e-20}}},
+		Discovery: &Discovery{
+			Date: "1898-Aug-13", Location: "Berlin", Site: "Berlin", Name: "G. Witt",
+			Text: "Discovered 1898 Aug. 13 by G. Witt at Berlin.", Citation: "Named for the Greek god of love.", Ref: "MPC",
+		},
+		CloseApproaches: []CloseApproach{{
+			OrbitID: "659", Body: "Earth", Time: time.Date(1900, 12, 27, 1, 29, 0, 0, time.UTC), JD: ptrTo(2415380.562),
+			Dist: ptrTo(0.3149), DistMin: ptrTo(0.3149), DistMax: ptrTo(0.3149), VRel: ptrTo(5.75), VInf: ptrTo(5.74), TSigma: "< 00:01",
+		}},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Lookup() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestClient_Lookup_Errors(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      int
+		body        string
+		wantMatches []Match
+		wantErr     error
+	}{
+		{
+			name:        "ambiguous status",
+			status:      http.StatusMultipleChoices,
+			body:        `{"code":"300","message":"specified search string matched multiple records","list":[{"pdes":"1P","name":"1P/Halley"},{"pdes":"1P-A","name":"1P-A"}]}`,
+			wantMatches: []Match{{Designation: "1P", Name: "1P/Halley"}, {Designation: "1P-A", Name: "1P-A"}},
+		},
+		{
+			name:        "ambiguous body",
+			status:      http.StatusOK,
+			body:        `{"list":[{"pdes":"2","name":"2 Pallas"}]}`,
+			wantMatches: []Match{{Designation: "2", Name: "2 Pallas"}},
+		},
+		{name: "not found status", status: http.StatusNotFound, body: `{"message":"specified object was not found"}`, wantErr: ErrNotFound},
+		{name: "not found body", status: http.StatusOK, body: `{"message":"specified object was not found"}`, wantErr: ErrNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, _ := apiServer(t, tt.status, tt.body)
+			c := &Client{LookupEndpoint: srv.URL}
+			_, err := c.Lookup(context.Background(), ObjectQuery{Search: "halley"})
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("Lookup() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantMatches != nil {
+				var amb *AmbiguousError
+				if !errors.As(err, &amb) {
+					t.Fatalf("Lookup() error = %v, want *AmbiguousError", err)
+				}
+				if diff := cmp.Diff(tt.wantMatches, amb.Matches); diff != "" {
+					t.Errorf("Matches mismatch (-want +got):\n%s", diff)
+				}
+				if amb.Search != "halley" {
+					t.Errorf("Search = %q, want halley", amb.Search)
+				}
+			}
+		})
+	}
+}
+
+func TestClient_LookupURL(t *testing.T) {
+	c := &Client{}
+	u, err := c.LookupURL(ObjectQuery{SpkID: 2000433})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := u.String(), "https://ssd-api.jpl.nasa.gov/sbdb.api?spk=2000433"; got != want {
+		t.Errorf("LookupURL() = %s, want %s", got, want)
+	}
+}
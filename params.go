@@ -0,0 +1,67 @@
+package sbdb
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// ssdTimeLayout is the time format accepted by the CAD, Fireball and Scout
+// APIs.
+const ssdTimeLayout = "2006-01-02T15:04:05"
+
+// params builds URL query parameters for the SSD APIs. Each setter skips
+// zero values so the API defaults stay in place.
+type params url.Values
+
+func (p params) setString(name, s string) {
+	if s != "" {
+		url.Values(p).Set(name, s)
+	}
+}
+
+func (p params) setTime(name string, t time.Time) {
+	if !t.IsZero() {
+		url.Values(p).Set(name, t.UTC().Format(ssdTimeLayout))
+	}
+}
+
+func (p params) setFloat(name string, f float64) {
+	if f != 0 {
+		url.Values(p).Set(name, strconv.FormatFloat(f, 'g', -1, 64))
+	}
+}
+
+func (p params) setBool(name string, b bool) {
+	if b {
+		url.Values(p).Set(name, strconv.FormatBool(b))
+	}
+}
+
+// setSort sets the sort parameter to the named order, prefixed with "-"
+// when desc is true.
+func (p params) setSort(name string, desc bool) {
+	if name == "" {
+		return
+	}
+	if desc {
+		name = "-" + name
+	}
+	url.Values(p).Set("sort", name)
+}
+
+func (p params) setLimit(n uint) {
+	if n > 0 {
+		url.Values(p).Set("limit", strconv.FormatUint(uint64(n), 10))
+	}
+}
+
+// checkTimeRange reports an error if both bounds are set and max is before
+// min. The names identify the bounds in the error.
+func checkTimeRange(minName string, min time.Time, maxName string, max time.Time) error {
+	if !min.IsZero() && !max.IsZero() && max.Before(min) {
+		return fmt.Errorf("%s %v is before %s %v", maxName, max, minName, min)
+	}
+	return nil
+}
@@ -0,0 +1,438 @@
+package sbdb
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// SyntaxError describes a problem found by ParseExpr.
+type SyntaxError struct {
+	Offset int    // Byte offset of the error in the input
+	Msg    string // Description of the problem
+}
+
+// Error implements the error interface.
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("syntax error at offset %d: %s", e.Offset, e.Msg)
+}
+
+// ParseExpr parses a textual constraint such as
+//
+//	(e > 0.9 AND q < 1.3) OR class = "APO"
+//
+// into an And/Or/ComparisonExpr tree. Comparisons take the form
+// field op value, where op is one of =, !=, <, >, <=, >= or =~ (regular
+// expression match). Ranges are written "field BETWEEN min AND max" and
+// definedness as "field IS NOT NULL" or "field IS NULL". AND binds more
+// tightly than OR, and parentheses group. Keywords are case-insensitive.
+// Values are numbers, double-quoted strings, or bare words.
+//
+// Errors are reported as *SyntaxError.
+func ParseExpr(s string) (Expr, error) {
+	p := &parser{lex: lexer{src: s}}
+	p.next()
+	e, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.tok.kind != tokEOF {
+		return nil, p.errorf("unexpected %s", p.tok)
+	}
+	return e, nil
+}
+
+// FormatExpr renders e in the syntax accepted by ParseExpr. Nested groups
+// are always parenthesized so the result parses back to the same tree.
+// ParseExpr never produces a group holding a single expression, so such
+// a group parses back as the expression it holds.
+func FormatExpr(e Expr) (string, error) {
+	var b strings.Builder
+	if err := formatExpr(&b, e, false); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+var formatOps = map[operator]string{
+	OpEQ: "=", OpNE: "!=", OpLT: "<", OpGT: ">", OpLE: "<=", OpGE: ">=", OpRE: "=~",
+}
+
+func formatExpr(b *strings.Builder, e Expr, nested bool) error {
+	var (
+		list []Expr
+		sep  string
+	)
+	switch e := e.(type) {
+	case And:
+		list, sep = e, " AND "
+	case Or:
+		list, sep = e, " OR "
+	case ComparisonExpr:
+		cmp, err := e.parse()
+		if err != nil {
+			return err
+		}
+		b.WriteString(string(cmp.field))
+		switch cmp.op {
+		case OpDF:
+			b.WriteString(" IS NOT NULL")
+		case OpND:
+			b.WriteString(" IS NULL")
+		case OpRG:
+			fmt.Fprintf(b, " BETWEEN %s AND %s", formatValue(cmp.args[0]), formatValue(cmp.args[1]))
+		default:
+			fmt.Fprintf(b, " %s %s", formatOps[cmp.op], formatValue(cmp.args[0]))
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported expression type %T", e)
+	}
+
+	if len(list) == 0 {
+		return fmt.Errorf("cannot format empty %s group", strings.TrimSpace(sep))
+	}
+	if nested {
+		b.WriteByte('(')
+	}
+	for i, sub := range list {
+		if i > 0 {
+			b.WriteString(sep)
+		}
+		if err := formatExpr(b, sub, true); err != nil {
+			return err
+		}
+	}
+	if nested {
+		b.WriteByte(')')
+	}
+	return nil
+}
+
+// formatValue writes numbers bare and quotes everything else.
+func formatValue(v string) string {
+	l := lexer{src: v}
+	if t := l.next(); t.kind == tokNumber && t.pos == 0 && l.pos == len(v) {
+		return v
+	}
+	return strconv.Quote(v)
+}
+
+type parser struct {
+	lex lexer
+	tok token
+}
+
+func (p *parser) next() {
+	p.tok = p.lex.next()
+}
+
+func (p *parser) errorf(format string, args ...any) error {
+	if p.tok.kind == tokError {
+		return &SyntaxError{Offset: p.tok.pos, Msg: p.tok.text}
+	}
+	return &SyntaxError{Offset: p.tok.pos, Msg: fmt.Sprintf(format, args...)}
+}
+
+func (p *parser) parseOr() (Expr, error) {
+	e, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	if !p.tok.isKeyword("OR") {
+		return e, nil
+	}
+	or := Or{e}
+	for p.tok.isKeyword("OR") {
+		p.next()
+		e, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		or = append(or, e)
+	}
+	return or, nil
+}
+
+func (p *parser) parseAnd() (Expr, error) {
+	e, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	if !p.tok.isKeyword("AND") {
+		return e, nil
+	}
+	and := And{e}
+	for p.tok.isKeyword("AND") {
+		p.next()
+		e, err := p.parsePrimary()
+		if err != nil {
+			return nil, err
+		}
+		and = append(and, e)
+	}
+	return and, nil
+}
+
+func (p *parser) parsePrimary() (Expr, error) {
+	if p.tok.kind == tokLParen {
+		p.next()
+		e, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.tok.kind != tokRParen {
+			return nil, p.errorf("expected ) but found %s", p.tok)
+		}
+		p.next()
+		return e, nil
+	}
+	if p.tok.kind != tokIdent || p.tok.isKeyword("AND", "OR", "BETWEEN", "IS", "NOT", "NULL") {
+		return nil, p.errorf("expected field name or ( but found %s", p.tok)
+	}
+	field := p.tok.text
+	p.next()
+
+	switch {
+	case p.tok.kind == tokOp:
+		op := p.tok.text
+		p.next()
+		v, err := p.parseValue(op == "=~")
+		if err != nil {
+			return nil, err
+		}
+		switch op {
+		case "=":
+			return EQ(field, v), nil
+		case "!=":
+			return NE(field, v), nil
+		case "<":
+			return LT(field, v), nil
+		case ">":
+			return GT(field, v), nil
+		case "<=":
+			return LE(field, v), nil
+		case ">=":
+			return GE(field, v), nil
+		default: // "=~"
+			return RE(field, v), nil
+		}
+	case p.tok.isKeyword("BETWEEN"):
+		p.next()
+		lo, err := p.parseValue(false)
+		if err != nil {
+			return nil, err
+		}
+		if !p.tok.isKeyword("AND") {
+			return nil, p.errorf("expected AND in BETWEEN but found %s", p.tok)
+		}
+		p.next()
+		hi, err := p.parseValue(false)
+		if err != nil {
+			return nil, err
+		}
+		return RG(field, lo, hi), nil
+	case p.tok.isKeyword("IS"):
+		p.next()
+		not := p.tok.isKeyword("NOT")
+		if not {
+			p.next()
+		}
+		if !p.tok.isKeyword("NULL") {
+			return nil, p.errorf("expected NULL but found %s", p.tok)
+		}
+		p.next()
+		if not {
+			return DF(field), nil
+		}
+		return ND(field), nil
+	default:
+		return nil, p.errorf("expected operator after %q but found %s", field, p.tok)
+	}
+}
+
+// parseValue parses a comparison value. Only regular expressions may
+// contain '|', since it separates values in the API's encoding.
+func (p *parser) parseValue(allowPipe bool) (string, error) {
+	switch p.tok.kind {
+	case tokNumber, tokString:
+	case tokIdent:
+		if p.tok.isKeyword("AND", "OR", "BETWEEN", "IS", "NOT", "NULL") {
+			return "", p.errorf("expected value but found %s", p.tok)
+		}
+	default:
+		return "", p.errorf("expected value but found %s", p.tok)
+	}
+	v := p.tok.text
+	if !allowPipe && strings.Contains(v, "|") {
+		return "", p.errorf("value may not contain '|'")
+	}
+	p.next()
+	return v, nil
+}
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokError
+	tokIdent
+	tokNumber
+	tokString
+	tokOp
+	tokLParen
+	tokRParen
+)
+
+type token struct {
+	kind tokenKind
+	text string // identifier, operator, or unquoted value; message for tokError
+	pos  int
+}
+
+func (t token) isKeyword(words ...string) bool {
+	if t.kind != tokIdent {
+		return false
+	}
+	for _, w := range words {
+		if strings.EqualFold(t.text, w) {
+			return true
+		}
+	}
+	return false
+}
+
+func (t token) String() string {
+	switch t.kind {
+	case tokEOF:
+		return "end of input"
+	case tokString:
+		return strconv.Quote(t.text)
+	default:
+		return fmt.Sprintf("%q", t.text)
+	}
+}
+
+type lexer struct {
+	src string
+	pos int
+}
+
+func (l *lexer) next() token {
+	for l.pos < len(l.src) {
+		r, size := utf8.DecodeRuneInString(l.src[l.pos:])
+		if !unicode.IsSpace(r) {
+			break
+		}
+		l.pos += size
+	}
+	start := l.pos
+	if start >= len(l.src) {
+		return token{kind: tokEOF, pos: start}
+	}
+	rest := l.src[start:]
+	switch c := rest[0]; {
+	case c == '(':
+		l.pos++
+		return token{kind: tokLParen, text: "(", pos: start}
+	case c == ')':
+		l.pos++
+		return token{kind: tokRParen, text: ")", pos: start}
+	case c == '"':
+		return l.lexString()
+	case isNumberStart(rest):
+		return l.lexNumber()
+	case isIdentByte(c):
+		for l.pos < len(l.src) && isIdentByte(l.src[l.pos]) {
+			l.pos++
+		}
+		return token{kind: tokIdent, text: l.src[start:l.pos], pos: start}
+	}
+	for _, op := range []string{"<=", ">=", "!=", "=~", "=", "<", ">"} {
+		if strings.HasPrefix(rest, op) {
+			l.pos += len(op)
+			return token{kind: tokOp, text: op, pos: start}
+		}
+	}
+	r, _ := utf8.DecodeRuneInString(rest)
+	return token{kind: tokError, text: fmt.Sprintf("unexpected character %q", r), pos: start}
+}
+
+func (l *lexer) lexString() token {
+	start := l.pos
+	i := start + 1
+	for i < len(l.src) {
+		switch l.src[i] {
+		case '\\':
+			i += 2
+			continue
+		case '"':
+			s, err := strconv.Unquote(l.src[start : i+1])
+			if err != nil {
+				return token{kind: tokError, text: "invalid escape in string", pos: start}
+			}
+			l.pos = i + 1
+			return token{kind: tokString, text: s, pos: start}
+		}
+		i++
+	}
+	return token{kind: tokError, text: "unterminated string", pos: start}
+}
+
+func (l *lexer) lexNumber() token {
+	start := l.pos
+	i := start
+	if l.src[i] == '+' || l.src[i] == '-' {
+		i++
+	}
+	for i < len(l.src) && (isDigit(l.src[i]) || l.src[i] == '.') {
+		i++
+	}
+	if i < len(l.src) && (l.src[i] == 'e' || l.src[i] == 'E') {
+		j := i + 1
+		if j < len(l.src) && (l.src[j] == '+' || l.src[j] == '-') {
+			j++
+		}
+		if j < len(l.src) && isDigit(l.src[j]) {
+			for j < len(l.src) && isDigit(l.src[j]) {
+				j++
+			}
+			i = j
+		}
+	}
+	if i < len(l.src) && isIdentByte(l.src[i]) && isIdentByte(l.src[start]) {
+		// A bare word that starts with digits, such as 73P.
+		for i < len(l.src) && isIdentByte(l.src[i]) {
+			i++
+		}
+		l.pos = i
+		return token{kind: tokIdent, text: l.src[start:i], pos: start}
+	}
+	text := l.src[start:i]
+	if _, err := strconv.ParseFloat(text, 64); err != nil || (i < len(l.src) && isIdentByte(l.src[i])) {
+		return token{kind: tokError, text: fmt.Sprintf("invalid number %q", text), pos: start}
+	}
+	l.pos = i
+	return token{kind: tokNumber, text: text, pos: start}
+}
+
+// isNumberStart reports whether s begins with a number, optionally signed.
+func isNumberStart(s string) bool {
+	if s != "" && (s[0] == '+' || s[0] == '-') {
+		s = s[1:]
+	}
+	if s != "" && s[0] == '.' {
+		s = s[1:]
+	}
+	return s != "" && isDigit(s[0])
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isIdentByte(c byte) bool {
+	return c == '_' || isDigit(c) || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
@@ -0,0 +1,176 @@
+package sbdb
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseExpr(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		want    Expr
+		wantPos int
+		wantErr bool
+	}{
+		{
+			name: "request example",
+			s:    `(e > 0.9 AND q < 1.3) OR class = "APO"`,
+			want: Or{And{GT("e", "0.9"), LT("q", "1.3")}, EQ("class", "APO")},
+		},
+		{
+			name: "single comparison",
+			s:    `H <= 22`,
+			want: LE("H", "22"),
+		},
+		{
+			name: "all operators",
+			s:    `a = 1 and b != 2 and c < 3 and d > 4 and e <= 5 and f >= 6 and g =~ "^x|y$"`,
+			want: And{EQ("a", "1"), NE("b", "2"), LT("c", "3"), GT("d", "4"), LE("e", "5"), GE("f", "6"), RE("g", "^x|y$")},
+		},
+		{
+			name: "between and null",
+			s:    `q BETWEEN 0.9 AND 1.3 AND albedo IS NOT NULL AND GM is null`,
+			want: And{RG("q", "0.9", "1.3"), DF("albedo"), ND("GM")},
+		},
+		{
+			name: "precedence",
+			s:    `a = 1 OR b = 2 AND c = 3`,
+			want: Or{EQ("a", "1"), And{EQ("b", "2"), EQ("c", "3")}},
+		},
+		{
+			name: "bare words and signed numbers",
+			s:    `pdes = 73P AND spec_B = Sq AND DT > -1.5e-3`,
+			want: And{EQ("pdes", "73P"), EQ("spec_B", "Sq"), GT("DT", "-1.5e-3")},
+		},
+		{
+			name: "escaped string",
+			s:    `full_name = "say \"hi\""`,
+			want: EQ("full_name", `say "hi"`),
+		},
+		{
+			name: "redundant parentheses",
+			s:    `((e > 0.9))`,
+			want: GT("e", "0.9"),
+		},
+		{name: "empty", s: ``, wantErr: true, wantPos: 0},
+		{name: "missing value", s: `e >`, wantErr: true, wantPos: 3},
+		{name: "missing operator", s: `e 0.9`, wantErr: true, wantPos: 2},
+		{name: "unclosed paren", s: `(e > 1`, wantErr: true, wantPos: 6},
+		{name: "trailing tokens", s: `e > 1 q`, wantErr: true, wantPos: 6},
+		{name: "unterminated string", s: `class = "APO`, wantErr: true, wantPos: 8},
+		{name: "bad character", s: `e > 1 & q < 2`, wantErr: true, wantPos: 6},
+		{name: "between without and", s: `q BETWEEN 1 OR 2`, wantErr: true, wantPos: 12},
+		{name: "is without null", s: `q IS NOT 1`, wantErr: true, wantPos: 9},
+		{name: "keyword as field", s: `AND = 1`, wantErr: true, wantPos: 0},
+		{name: "pipe in value", s: `class = "A|B"`, wantErr: true, wantPos: 8},
+		{name: "bad number", s: `e > 1.2.3`, wantErr: true, wantPos: 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseExpr(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseExpr() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				var se *SyntaxError
+				if !errors.As(err, &se) {
+					t.Fatalf("ParseExpr() error = %T, want *SyntaxError", err)
+				}
+				if se.Offset != tt.wantPos {
+					t.Errorf("Offset = %d, want %d (%v)", se.Offset, tt.wantPos, se)
+				}
+				return
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestFormatExpr(t *testing.T) {
+	tests := []struct {
+		name    string
+		e       Expr
+		want    string
+		back    Expr // Result of parsing want, if different from e
+		wantErr bool
+	}{
+		{
+			name: "request example",
+			e:    Or{And{GT("e", "0.9"), LT("q", "1.3")}, EQ("class", "APO")},
+			want: `(e > 0.9 AND q < 1.3) OR class = "APO"`,
+		},
+		{
+			name: "all operators",
+			e:    And{NE("b", "2"), GE("f", "-6"), RE("g", "^x|y$"), RG("q", "1", "2"), DF("albedo"), ND("GM")},
+			want: `b != 2 AND f >= -6 AND g =~ "^x|y$" AND q BETWEEN 1 AND 2 AND albedo IS NOT NULL AND GM IS NULL`,
+		},
+		{
+			name: "nested same kind",
+			e:    And{And{EQ("a", "1"), EQ("c", "2")}, EQ("b", "x y")},
+			want: `(a = 1 AND c = 2) AND b = "x y"`,
+		},
+		{
+			name: "single expression groups",
+			e:    Or{And{EQ("a", "1")}, EQ("b", "2")},
+			want: `(a = 1) OR b = 2`,
+			back: Or{EQ("a", "1"), EQ("b", "2")},
+		},
+		{name: "empty group", e: And{}, wantErr: true},
+		{name: "malformed comparison", e: ComparisonExpr("e"), wantErr: true},
+		{name: "nil", e: nil, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FormatExpr(tt.e)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("FormatExpr() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("FormatExpr() = %s, want %s", got, tt.want)
+			}
+			if tt.wantErr {
+				return
+			}
+			back, err := ParseExpr(got)
+			if err != nil {
+				t.Fatalf("ParseExpr(%q) error = %v", got, err)
+			}
+			want := tt.back
+			if want == nil {
+				want = tt.e
+			}
+			if diff := cmp.Diff(want, back); diff != "" {
+				t.Errorf("round trip mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func FuzzParseExpr(f *testing.F) {
+	f.Add(`(e > 0.9 AND q < 1.3) OR class = "APO"`)
+	f.Add(`q BETWEEN 1 AND 2 AND albedo IS NOT NULL`)
+	f.Add(`name =~ "^C\"e"`)
+	f.Add(`a = "x" OR b BETWEEN "1" AND 2`)
+	f.Fuzz(func(t *testing.T, s string) {
+		e, err := ParseExpr(s)
+		if err != nil {
+			return
+		}
+		out, err := FormatExpr(e)
+		if err != nil {
+			t.Fatalf("FormatExpr(ParseExpr(%q)) error = %v", s, err)
+		}
+		back, err := ParseExpr(out)
+		if err != nil {
+			t.Fatalf("ParseExpr(%q) error = %v", out, err)
+		}
+		if diff := cmp.Diff(e, back); diff != "" {
+			t.Fatalf("round trip of %q mismatch (-want +got):\n%s", s, diff)
+		}
+	})
+}
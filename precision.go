@@ -0,0 +1,184 @@
+package sbdb
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strconv"
+)
+
+// Decimal is a number held in the exact decimal text the API returned, so
+// no digits are lost to float64 rounding. Request full-precision values
+// with Filter.FullPrecision.
+type Decimal string
+
+// String returns the decimal text.
+func (d Decimal) String() string {
+	return string(d)
+}
+
+// Float64 returns d rounded to the nearest float64.
+func (d Decimal) Float64() (float64, error) {
+	return strconv.ParseFloat(string(d), 64)
+}
+
+// BigFloat returns d as a *big.Float with enough precision to represent
+// every significant digit of the decimal text.
+func (d Decimal) BigFloat() (*big.Float, error) {
+	var digits uint
+	for _, c := range d {
+		if c >= '0' && c <= '9' {
+			digits++
+		}
+	}
+	// log2(10) < 3.33 bits per digit, plus headroom for rounding.
+	prec := digits*333/100 + 8
+	if prec < 64 {
+		prec = 64
+	}
+	f, _, err := big.ParseFloat(string(d), 10, prec, big.ToNearestEven)
+	if err != nil {
+		return nil, fmt.Errorf("invalid decimal %q: %w", string(d), err)
+	}
+	return f, nil
+}
+
+// Decimal returns the value of field as a Decimal without converting it
+// through float64, or nil if the value is missing or not numeric.
+func (r Record) Decimal(field Field) *Decimal {
+	if r[field] == nil {
+		return nil
+	}
+	var s string
+	switch v := r[field].(type) {
+	case json.Number:
+		s = v.String()
+	case string:
+		s = v
+	case float64:
+		s = strconv.FormatFloat(v, 'g', -1, 64)
+	case int:
+		s = strconv.Itoa(v)
+	default:
+		logFailedTypeAssert("Decimal", field, r[field])
+		return nil
+	}
+	if !decimalText.MatchString(s) {
+		logFailedTypeAssert("Decimal(string)", field, r[field])
+		return nil
+	}
+	d := Decimal(s)
+	return &d
+}
+
+// BigFloat returns the value of field as a *big.Float that preserves every
+// digit the API returned, or nil if the value is missing or not numeric.
+func (r Record) BigFloat(field Field) *big.Float {
+	d := r.Decimal(field)
+	if d == nil {
+		return nil
+	}
+	f, err := d.BigFloat()
+	if err != nil {
+		logFailedTypeAssert("BigFloat", field, r[field])
+		return nil
+	}
+	return f
+}
+
+// decimalText matches plain decimal numbers: an optional sign, digits
+// with an optional fraction, and an optional exponent. It rejects the
+// NaN, Inf and hexadecimal forms strconv.ParseFloat also accepts.
+var decimalText = regexp.MustCompile(`^[+-]?([0-9]+(\.[0-9]*)?|\.[0-9]+)([eE][+-]?[0-9]+)?$`)
+
+// PreciseBody pairs a Body with exact decimal copies of its orbital
+// elements and their uncertainties.
+type PreciseBody struct {
+	Body
+	OrbitDecimal       OrbitDecimal
+	UncertaintyDecimal UncertaintyDecimal
+}
+
+// OrbitDecimal holds the numeric elements of Orbit as exact decimals.
+type OrbitDecimal struct {
+	Epoch           *Decimal // Epoch of osculation (JD)
+	EpochMJD        *Decimal // Epoch of osculation (MJD)
+	Eccentricity    *Decimal // Orbital eccentricity
+	SemimajorAxis   *Decimal // Semi-major axis (au)
+	PerihelionDist  *Decimal // Perihelion distance (au)
+	Inclination     *Decimal // Inclination (deg)
+	AscNode         *Decimal // Longitude of ascending node (deg)
+	PeriapsisArg    *Decimal // Argument of periapsis (deg)
+	MeanAnomaly     *Decimal // Mean anomaly at epoch (deg)
+	PeriapsisTime   *Decimal // Time of periapsis (JD)
+	OrbitalPeriod   *Decimal // Orbital period (days)
+	OrbitalPeriodYr *Decimal // Orbital period (years)
+	MeanMotion      *Decimal // Mean motion (deg/day)
+	AphelionDist    *Decimal // Aphelion distance (au)
+}
+
+// UncertaintyDecimal holds the values of Uncertainty as exact decimals.
+type UncertaintyDecimal struct {
+	SigmaEcc     *Decimal // Uncertainty of eccentricity
+	SigmaA       *Decimal // Uncertainty of semi-major axis (au)
+	SigmaQ       *Decimal // Uncertainty of perihelion distance (au)
+	SigmaI       *Decimal // Uncertainty of inclination (deg)
+	SigmaAscNode *Decimal // Uncertainty of ascending node (deg)
+	SigmaPeriArg *Decimal // Uncertainty of periapsis argument (deg)
+	SigmaTP      *Decimal // Uncertainty of time of periapsis (JD)
+	SigmaMA      *Decimal // Uncertainty of mean anomaly (deg)
+	SigmaPeriod  *Decimal // Uncertainty of orbital period (days)
+	SigmaN       *Decimal // Uncertainty of mean motion (deg/day)
+	SigmaAD      *Decimal // Uncertainty of aphelion distance (au)
+}
+
+// PreciseBodies converts the payload data into PreciseBody values. Use it
+// with Filter.FullPrecision to compare orbital elements without float64
+// rounding.
+func (p *Payload) PreciseBodies() ([]PreciseBody, error) {
+	records, err := p.Records()
+	if err != nil {
+		return nil, err
+	}
+	bodies := make([]PreciseBody, len(records))
+	for i, r := range records {
+		bodies[i] = r.preciseBody()
+	}
+	return bodies, nil
+}
+
+func (r Record) preciseBody() PreciseBody {
+	return PreciseBody{
+		Body: r.body(),
+		OrbitDecimal: OrbitDecimal{
+			Epoch:           r.Decimal(Epoch),
+			EpochMJD:        r.Decimal(EpochMJD),
+			Eccentricity:    r.Decimal(Eccentricity),
+			SemimajorAxis:   r.Decimal(SemimajorAxis),
+			PerihelionDist:  r.Decimal(PerihelionDist),
+			Inclination:     r.Decimal(Inclination),
+			AscNode:         r.Decimal(AscNode),
+			PeriapsisArg:    r.Decimal(PeriapsisArg),
+			MeanAnomaly:     r.Decimal(MeanAnomaly),
+			PeriapsisTime:   r.Decimal(PeriapsisTime),
+			OrbitalPeriod:   r.Decimal(OrbitalPeriod),
+			OrbitalPeriodYr: r.Decimal(OrbitalPeriodYr),
+			MeanMotion:      r.Decimal(MeanMotion),
+			AphelionDist:    r.Decimal(AphelionDist),
+		},
+		UncertaintyDecimal: UncertaintyDecimal{
+			SigmaEcc:     r.Decimal(SigmaEcc),
+			SigmaA:       r.Decimal(SigmaA),
+			SigmaQ:       r.Decimal(SigmaQ),
+			SigmaI:       r.Decimal(SigmaI),
+			SigmaAscNode: r.Decimal(SigmaAscNode),
+			SigmaPeriArg: r.Decimal(SigmaPeriArg),
+			SigmaTP:      r.Decimal(SigmaTP),
+			SigmaMA:      r.Decimal(SigmaMA),
+			SigmaPeriod:  r.Decimal(SigmaPeriod),
+			SigmaN:       r.Decimal(SigmaN),
+			SigmaAD:      r.Decimal(SigmaAD),
+		},
+	}
+}
@@ -0,0 +1,107 @@
+package sbdb
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestRecord_Decimal(t *testing.T) {
+	tests := []struct {
+		name  string
+		r     Record
+		field Field
+		want  *Decimal
+	}{
+		{"json.Number", Record{Eccentricity: json.Number("0.07772366686528209876")}, Eccentricity, ptrTo(Decimal("0.07772366686528209876"))},
+		{"string", Record{Eccentricity: "1.5e-10"}, Eccentricity, ptrTo(Decimal("1.5e-10"))},
+		{"float64", Record{Eccentricity: 0.25}, Eccentricity, ptrTo(Decimal("0.25"))},
+		{"int", Record{Eccentricity: 3}, Eccentricity, ptrTo(Decimal("3"))},
+		{"nil", Record{Eccentricity: nil}, Eccentricity, nil},
+		{"missing", Record{}, Eccentricity, nil},
+		{"not numeric", Record{Eccentricity: "abc"}, Eccentricity, nil},
+		{"signed exponent", Record{Eccentricity: "-.5E+3"}, Eccentricity, ptrTo(Decimal("-.5E+3"))},
+		{"beyond float64 range", Record{Eccentricity: "1e400"}, Eccentricity, ptrTo(Decimal("1e400"))},
+		{"NaN", Record{Eccentricity: "NaN"}, Eccentricity, nil},
+		{"Inf", Record{Eccentricity: "-Inf"}, Eccentricity, nil},
+		{"float64 NaN", Record{Eccentricity: math.NaN()}, Eccentricity, nil},
+		{"hex float", Record{Eccentricity: "0x1p-2"}, Eccentricity, nil},
+		{"underscores", Record{Eccentricity: "1_000"}, Eccentricity, nil},
+		{"bare dot", Record{Eccentricity: "."}, Eccentricity, nil},
+		{"wrong type", Record{Eccentricity: true}, Eccentricity, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.r.Decimal(tt.field)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Decimal() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDecimal_BigFloat(t *testing.T) {
+	tests := []struct {
+		name    string
+		d       Decimal
+		want    string
+		wantErr bool
+	}{
+		{"beyond float64", "2459600.50000000000000123", "2459600.50000000000000123", false},
+		{"small", "0.07772366686528209876", "0.07772366686528209876", false},
+		{"exponent", "1.5e-10", "1.5e-10", false},
+		{"invalid", "abc", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.d.BigFloat()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("BigFloat() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			want, _, _ := big.ParseFloat(tt.want, 10, got.Prec(), big.ToNearestEven)
+			if got.Cmp(want) != 0 {
+				t.Errorf("BigFloat() = %s, want %s", got.Text('g', -1), tt.want)
+			}
+			f, _ := tt.d.Float64()
+			if g, _ := got.Float64(); g != f {
+				t.Errorf("BigFloat().Float64() = %v, Float64() = %v", g, f)
+			}
+		})
+	}
+}
+
+func TestPayload_PreciseBodies(t *testing.T) {
+	p, err := Decode(bytes.NewBufferString(`{"fields":["spkid","e","tp","sigma_e"],"data":[["2000433","0.2228359407071628163","2459935.1683948571","4.8e-9"],["2000001",null,"2459000",null]],"count":2}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := p.PreciseBodies()
+	if err != nil {
+		t.Fatal(err)
+	}
+	bodies, err := p.Bodies()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []PreciseBody{
+		{
+			Body:               bodies[0],
+			OrbitDecimal:       OrbitDecimal{Eccentricity: ptrTo(Decimal("0.2228359407071628163")), PeriapsisTime: ptrTo(Decimal("2459935.1683948571"))},
+			UncertaintyDecimal: UncertaintyDecimal{SigmaEcc: ptrTo(Decimal("4.8e-9"))},
+		},
+		{
+			Body:         bodies[1],
+			OrbitDecimal: OrbitDecimal{PeriapsisTime: ptrTo(Decimal("2459000"))},
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("PreciseBodies() mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -166,6 +166,50 @@ func (c ComparisonExpr) MarshalJSON() ([]byte, error) {
 	return json.Marshal(string(c))
 }
 
+// UnmarshalExpr decodes the JSON form of an expression, as produced by
+// marshaling an And, Or or ComparisonExpr, back into an Expr.
+func UnmarshalExpr(data []byte) (Expr, error) {
+	var raw any
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	return exprFromJSON(raw)
+}
+
+func exprFromJSON(raw any) (Expr, error) {
+	switch v := raw.(type) {
+	case string:
+		return ComparisonExpr(v), nil
+	case map[string]any:
+		if len(v) != 1 {
+			return nil, fmt.Errorf("expression object must have exactly one key, got %d", len(v))
+		}
+		for k, sub := range v {
+			list, ok := sub.([]any)
+			if !ok {
+				return nil, fmt.Errorf("%s: expected array, got %T", k, sub)
+			}
+			exprs := make([]Expr, len(list))
+			for i, item := range list {
+				e, err := exprFromJSON(item)
+				if err != nil {
+					return nil, err
+				}
+				exprs[i] = e
+			}
+			switch k {
+			case "AND":
+				return And(exprs), nil
+			case "OR":
+				return Or(exprs), nil
+			default:
+				return nil, fmt.Errorf("unknown logical operator %q", k)
+			}
+		}
+	}
+	return nil, fmt.Errorf("unexpected expression value %T", raw)
+}
+
 type operator uint
 
 const (
@@ -267,11 +311,52 @@ func (fs FieldSet) List() []string {
 	return out
 }
 
+// Fields returns the fields in sorted order.
+func (fs FieldSet) Fields() []Field {
+	out := make([]Field, 0, len(fs))
+	for _, f := range fs.List() {
+		out = append(out, Field(f))
+	}
+	return out
+}
+
 // String implements fmt.Stringer and returns a comma separated field list.
 func (fs FieldSet) String() string {
 	return strings.Join(fs.List(), ",")
 }
 
+// SortKey orders results by a single field.
+type SortKey struct {
+	Field Field
+	Desc  bool // Sort in descending order
+}
+
+// SortKeys is an ordered list of sort keys; earlier keys take precedence.
+type SortKeys []SortKey
+
+// Asc returns a SortKey ordering results by f in ascending order.
+func Asc(f Field) SortKey { return SortKey{Field: f} }
+
+// Desc returns a SortKey ordering results by f in descending order.
+func Desc(f Field) SortKey { return SortKey{Field: f, Desc: true} }
+
+// String returns the key as the API expects it: the field name, prefixed
+// with '-' for descending order.
+func (s SortKey) String() string {
+	if s.Desc {
+		return "-" + s.Field.String()
+	}
+	return s.Field.String()
+}
+
+func (s SortKeys) String() string {
+	parts := make([]string, len(s))
+	for i, k := range s {
+		parts[i] = k.String()
+	}
+	return strings.Join(parts, ",")
+}
+
 // Filter defines the search parameters for a query. It mirrors the
 // parameters described in the SBDB Query API documentation.
 type Filter struct {
@@ -291,6 +376,13 @@ type Filter struct {
 	// FieldConstraints applies advanced field-level filters encoded as
 	// AND/OR expressions. See the SBDB filter documentation for syntax.
 	FieldConstraints Expr
+	// FullPrecision, when true, requests numeric values at full precision.
+	// Use Payload.PreciseBodies or Record.Decimal to read them without
+	// float64 rounding.
+	FullPrecision bool
+	// Sort orders results by up to 3 fields. A stable order is needed for
+	// paging with LimitFrom to return consistent results across runs.
+	Sort SortKeys
 }
 
 // Values converts the Filter into URL query parameters.
@@ -301,9 +393,15 @@ func (f Filter) Values() (url.Values, error) {
 	if len(f.Classes) > 3 {
 		return nil, fmt.Errorf("len(ClassFilters) = %d, max = 3", len(f.Classes))
 	}
+	if len(f.Sort) > 3 {
+		return nil, fmt.Errorf("len(SortKeys) = %d, max = 3", len(f.Sort))
+	}
 
 	v := url.Values{}
 	v.Set("fields", f.Fields.String())
+	if len(f.Sort) > 0 {
+		v.Set("sort", f.Sort.String())
+	}
 	if f.Limit > 0 {
 		v.Set("limit", strconv.FormatUint(uint64(f.Limit), 10))
 	}
@@ -325,6 +423,9 @@ func (f Filter) Values() (url.Values, error) {
 	if f.ExcludeFragments {
 		v.Set("sb-xfrag", strconv.FormatBool(f.ExcludeFragments))
 	}
+	if f.FullPrecision {
+		v.Set("full-prec", strconv.FormatBool(f.FullPrecision))
+	}
 	if len(f.Classes) > 0 {
 		v.Set("sb-class", f.Classes.String())
 	}
@@ -377,6 +377,39 @@ func TestFilter_Values(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "Full Precision - Valid",
+			fields: Filter{
+				Fields:        NewFieldSet("field"),
+				FullPrecision: true,
+			},
+			want: url.Values{
+				"fields":    []string{"field"},
+				"full-prec": []string{"true"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sort - Valid",
+			fields: Filter{
+				Fields: NewFieldSet("field"),
+				Sort:   SortKeys{Asc(MOID), Desc(H)},
+			},
+			want: url.Values{
+				"fields": []string{"field"},
+				"sort":   []string{"moid,-H"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sort - Too Many",
+			fields: Filter{
+				Fields: NewFieldSet("field"),
+				Sort:   SortKeys{Asc(MOID), Desc(H), Asc(Albedo), Asc(Diameter)},
+			},
+			want:    nil,
+			wantErr: true,
+		},
 		{
 			name: "Field Constraints - Valid",
 			fields: Filter{
@@ -402,6 +435,8 @@ func TestFilter_Values(t *testing.T) {
 				MustHaveSatellite: tt.fields.MustHaveSatellite,
 				ExcludeFragments:  tt.fields.ExcludeFragments,
 				FieldConstraints:  tt.fields.FieldConstraints,
+				Sort:              tt.fields.Sort,
+				FullPrecision:     tt.fields.FullPrecision,
 			}
 			got, err := f.Values()
 			if (err != nil) != tt.wantErr {
@@ -501,3 +536,67 @@ func Test_Ops(t *testing.T) {
 		})
 	}
 }
+
+func TestUnmarshalExpr(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    Expr
+		wantErr bool
+	}{
+		{
+			name: "comparison",
+			data: `"e|GT|0.9"`,
+			want: ComparisonExpr("e|GT|0.9"),
+		},
+		{
+			name: "nested",
+			data: `{"OR":[{"AND":["e|GT|0.9","q|LT|1.3"]},"class|EQ|APO"]}`,
+			want: Or{And{GT("e", "0.9"), LT("q", "1.3")}, EQ("class", "APO")},
+		},
+		{name: "unknown operator", data: `{"XOR":[]}`, wantErr: true},
+		{name: "two keys", data: `{"AND":[],"OR":[]}`, wantErr: true},
+		{name: "not an array", data: `{"AND":"e|DF"}`, wantErr: true},
+		{name: "number", data: `1`, wantErr: true},
+		{name: "invalid json", data: `{`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := UnmarshalExpr([]byte(tt.data))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("UnmarshalExpr() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSortKeys_String(t *testing.T) {
+	tests := []struct {
+		name string
+		s    SortKeys
+		want string
+	}{
+		{name: "Empty", s: SortKeys{}, want: ""},
+		{name: "Ascending", s: SortKeys{Asc(MOID)}, want: "moid"},
+		{name: "Descending", s: SortKeys{Desc(Diameter)}, want: "-diameter"},
+		{name: "Multiple", s: SortKeys{Asc(Class), Desc(H), Asc(SpkID)}, want: "class,-H,spkid"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.s.String(); got != tt.want {
+				t.Errorf("String() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFieldSet_Fields(t *testing.T) {
+	got := NewFieldSet(SpkID, Eccentricity, FullName).Fields()
+	want := []Field{Eccentricity, FullName, SpkID}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Fields() mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,191 @@
+package sbdb
+
+import "fmt"
+
+// ValueType is the Go type a Field's values decode to.
+type ValueType uint
+
+const (
+	TypeString ValueType = iota + 1 // Decodes to *string
+	TypeFloat                       // Decodes to *float64
+	TypeInt                         // Decodes to *int
+	TypeBool                        // Decodes to *bool
+)
+
+var valueTypeNames = map[ValueType]string{
+	TypeString: "string", TypeFloat: "float", TypeInt: "int", TypeBool: "bool",
+}
+
+func (t ValueType) String() string {
+	if s, ok := valueTypeNames[t]; ok {
+		return s
+	}
+	return fmt.Sprintf("Invalid ValueType(%d)", t)
+}
+
+// Section identifies the Body sub-struct a Field is decoded into.
+type Section uint
+
+const (
+	SectionIdentity    Section = iota + 1 // Body.Identity
+	SectionOrbit                          // Body.Orbit
+	SectionUncertainty                    // Body.Uncertainty
+	SectionSolution                       // Body.Solution
+	SectionQuality                        // Body.Quality
+	SectionNonGrav                        // Body.NonGrav
+	SectionPhysical                       // Body.Physical
+)
+
+var sectionNames = map[Section]string{
+	SectionIdentity: "Identity", SectionOrbit: "Orbit", SectionUncertainty: "Uncertainty",
+	SectionSolution: "Solution", SectionQuality: "Quality", SectionNonGrav: "NonGrav",
+	SectionPhysical: "Physical",
+}
+
+func (s Section) String() string {
+	if n, ok := sectionNames[s]; ok {
+		return n
+	}
+	return fmt.Sprintf("Invalid Section(%d)", s)
+}
+
+// FieldInfo describes a Field: the type its values decode to, its unit,
+// where it lives in Body, and whether the SBDB API accepts it in
+// constraint expressions.
+type FieldInfo struct {
+	Field       Field
+	Type        ValueType
+	Unit        string // Physical unit; empty for dimensionless values
+	Description string
+	Section     Section
+	// Constraint reports whether the field may be used in
+	// Filter.FieldConstraints. Derived and display-only fields, such as
+	// calendar dates and unit conversions, are not filterable.
+	Constraint bool
+}
+
+// fieldInfos lists every Field constant in declaration order.
+var fieldInfos = []FieldInfo{
+	{SpkID, TypeInt, "", "SPICE identifier for the body", SectionIdentity, true},
+	{FullName, TypeString, "", "Complete object designation", SectionIdentity, false},
+	{Kind, TypeString, "", "Body kind, e.g. asteroid or comet", SectionIdentity, true},
+	{PDes, TypeString, "", "Primary designation", SectionIdentity, true},
+	{Name, TypeString, "", "IAU name", SectionIdentity, true},
+	{Prefix, TypeString, "", "Numbered prefix", SectionIdentity, true},
+	{Class, TypeString, "", "Dynamical class", SectionIdentity, true},
+	{NEO, TypeBool, "", "Near Earth Object flag", SectionIdentity, true},
+	{PHA, TypeBool, "", "Potentially Hazardous Asteroid flag", SectionIdentity, true},
+	{Sats, TypeInt, "", "Number of known satellites", SectionIdentity, true},
+	{TJupiter, TypeFloat, "", "Tisserand parameter w.r.t. Jupiter", SectionIdentity, true},
+	{MOID, TypeFloat, "au", "Earth minimum orbit intersection distance", SectionIdentity, true},
+	{MOIDLD, TypeFloat, "LD", "Earth MOID", SectionIdentity, false},
+	{MOIDJupiter, TypeFloat, "au", "Jupiter MOID", SectionIdentity, true},
+	{OrbitID, TypeString, "", "Orbit solution identifier", SectionOrbit, true},
+	{Epoch, TypeFloat, "JD", "Reference epoch", SectionOrbit, true},
+	{EpochMJD, TypeFloat, "MJD", "Reference epoch", SectionOrbit, true},
+	{EpochCal, TypeString, "", "Reference epoch (calendar)", SectionOrbit, false},
+	{Equinox, TypeString, "", "Reference frame", SectionOrbit, true},
+	{Eccentricity, TypeFloat, "", "Orbital eccentricity", SectionOrbit, true},
+	{SemimajorAxis, TypeFloat, "au", "Semi-major axis", SectionOrbit, true},
+	{PerihelionDist, TypeFloat, "au", "Perihelion distance", SectionOrbit, true},
+	{Inclination, TypeFloat, "deg", "Inclination to the ecliptic", SectionOrbit, true},
+	{AscNode, TypeFloat, "deg", "Longitude of ascending node", SectionOrbit, true},
+	{PeriapsisArg, TypeFloat, "deg", "Argument of periapsis", SectionOrbit, true},
+	{MeanAnomaly, TypeFloat, "deg", "Mean anomaly at epoch", SectionOrbit, true},
+	{PeriapsisTime, TypeFloat, "JD", "Time of periapsis passage", SectionOrbit, true},
+	{PeriapsisTimeCal, TypeString, "", "Time of periapsis passage (calendar)", SectionOrbit, false},
+	{OrbitalPeriod, TypeFloat, "days", "Orbital period", SectionOrbit, true},
+	{OrbitalPeriodYr, TypeFloat, "years", "Orbital period", SectionOrbit, false},
+	{MeanMotion, TypeFloat, "deg/day", "Mean motion", SectionOrbit, true},
+	{AphelionDist, TypeFloat, "au", "Aphelion distance", SectionOrbit, true},
+	{SigmaEcc, TypeFloat, "", "1-sigma uncertainty of eccentricity", SectionUncertainty, true},
+	{SigmaA, TypeFloat, "au", "1-sigma uncertainty of semi-major axis", SectionUncertainty, true},
+	{SigmaQ, TypeFloat, "au", "1-sigma uncertainty of perihelion distance", SectionUncertainty, true},
+	{SigmaI, TypeFloat, "deg", "1-sigma uncertainty of inclination", SectionUncertainty, true},
+	{SigmaAscNode, TypeFloat, "deg", "1-sigma uncertainty of ascending node", SectionUncertainty, true},
+	{SigmaPeriArg, TypeFloat, "deg", "1-sigma uncertainty of periapsis argument", SectionUncertainty, true},
+	{SigmaTP, TypeFloat, "JD", "1-sigma uncertainty of time of periapsis", SectionUncertainty, true},
+	{SigmaMA, TypeFloat, "deg", "1-sigma uncertainty of mean anomaly", SectionUncertainty, true},
+	{SigmaPeriod, TypeFloat, "days", "1-sigma uncertainty of orbital period", SectionUncertainty, true},
+	{SigmaN, TypeFloat, "deg/day", "1-sigma uncertainty of mean motion", SectionUncertainty, true},
+	{SigmaAD, TypeFloat, "au", "1-sigma uncertainty of aphelion distance", SectionUncertainty, true},
+	{Source, TypeString, "", "Source of orbit solution", SectionSolution, true},
+	{SolutionDate, TypeString, "", "Solution date", SectionSolution, true},
+	{Producer, TypeString, "", "Producer of orbit solution", SectionSolution, true},
+	{DataArc, TypeInt, "days", "Data-arc span", SectionSolution, true},
+	{FirstObs, TypeString, "", "First observation date", SectionSolution, true},
+	{LastObs, TypeString, "", "Last observation date", SectionSolution, true},
+	{ObsUsed, TypeInt, "", "Number of observations used", SectionSolution, true},
+	{DelayObsUsed, TypeInt, "", "Number of delay observations used", SectionSolution, true},
+	{DopplerObsUsed, TypeInt, "", "Number of Doppler observations used", SectionSolution, true},
+	{TwoBody, TypeBool, "", "Two-body approximation flag", SectionQuality, true},
+	{PEUsed, TypeString, "", "Planetary ephemeris used", SectionQuality, true},
+	{SBUsed, TypeString, "", "Small-body perturbers used", SectionQuality, true},
+	{ConditionCode, TypeInt, "", "Orbit uncertainty condition code", SectionQuality, true},
+	{RMS, TypeFloat, "arcsec", "RMS residual", SectionQuality, true},
+	{A1, TypeFloat, "au/d^2", "Non-gravitational acceleration parameter A1", SectionNonGrav, true},
+	{A2, TypeFloat, "au/d^2", "Non-gravitational acceleration parameter A2", SectionNonGrav, true},
+	{A3, TypeFloat, "au/d^2", "Non-gravitational acceleration parameter A3", SectionNonGrav, true},
+	{DT, TypeFloat, "days", "Non-gravitational time parameter", SectionNonGrav, true},
+	{S0, TypeFloat, "", "Non-gravitational scale factor", SectionNonGrav, true},
+	{A1Sigma, TypeFloat, "au/d^2", "1-sigma uncertainty of A1", SectionNonGrav, true},
+	{A2Sigma, TypeFloat, "au/d^2", "1-sigma uncertainty of A2", SectionNonGrav, true},
+	{A3Sigma, TypeFloat, "au/d^2", "1-sigma uncertainty of A3", SectionNonGrav, true},
+	{DTSigma, TypeFloat, "days", "1-sigma uncertainty of DT", SectionNonGrav, true},
+	{S0Sigma, TypeFloat, "", "1-sigma uncertainty of S0", SectionNonGrav, true},
+	{H, TypeFloat, "", "Absolute magnitude H", SectionPhysical, true},
+	{G, TypeFloat, "", "Photometric slope parameter G", SectionPhysical, true},
+	{M1, TypeFloat, "", "Photometric parameter M1", SectionPhysical, true},
+	{K1, TypeFloat, "", "Photometric parameter K1", SectionPhysical, true},
+	{M2, TypeFloat, "", "Photometric parameter M2", SectionPhysical, true},
+	{K2, TypeFloat, "", "Photometric parameter K2", SectionPhysical, true},
+	{PC, TypeFloat, "", "Photometric color index PC", SectionPhysical, true},
+	{HSigma, TypeFloat, "", "1-sigma uncertainty of H", SectionPhysical, true},
+	{Diameter, TypeFloat, "km", "Diameter", SectionPhysical, true},
+	{Extent, TypeString, "km", "Physical extent", SectionPhysical, true},
+	{GM, TypeFloat, "km^3/s^2", "Gravitational parameter", SectionPhysical, true},
+	{Density, TypeFloat, "g/cm^3", "Bulk density", SectionPhysical, true},
+	{RotPer, TypeFloat, "hours", "Rotation period", SectionPhysical, true},
+	{Pole, TypeString, "deg", "Pole orientation", SectionPhysical, true},
+	{Albedo, TypeFloat, "", "Geometric albedo", SectionPhysical, true},
+	{BV, TypeFloat, "", "B-V color index", SectionPhysical, true},
+	{UB, TypeFloat, "", "U-B color index", SectionPhysical, true},
+	{IR, TypeFloat, "", "Infrared color index", SectionPhysical, true},
+	{SpecT, TypeString, "", "Spectral taxonomy", SectionPhysical, true},
+	{SpecB, TypeString, "", "Spectral bin", SectionPhysical, true},
+	{DiameterSigma, TypeFloat, "km", "1-sigma uncertainty of diameter", SectionPhysical, true},
+}
+
+var fieldIndex = func() map[Field]int {
+	m := make(map[Field]int, len(fieldInfos))
+	for i, info := range fieldInfos {
+		m[info.Field] = i
+	}
+	return m
+}()
+
+// Info returns the registry entry for f, and false if f is not a known
+// SBDB field.
+func (f Field) Info() (FieldInfo, bool) {
+	i, ok := fieldIndex[f]
+	if !ok {
+		return FieldInfo{}, false
+	}
+	return fieldInfos[i], true
+}
+
+// Fields returns the registry entries for every known field, in the
+// order the Field constants are declared.
+func Fields() []FieldInfo {
+	return append([]FieldInfo(nil), fieldInfos...)
+}
+
+// AllFields returns every known Field, in the order the constants are
+// declared.
+func AllFields() []Field {
+	out := make([]Field, len(fieldInfos))
+	for i, info := range fieldInfos {
+		out[i] = info.Field
+	}
+	return out
+}
@@ -0,0 +1,101 @@
+package sbdb
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestFieldInfos checks the registry against the Body struct so the two
+// cannot drift apart.
+func TestFieldInfos(t *testing.T) {
+	kinds := map[reflect.Kind]ValueType{
+		reflect.String:  TypeString,
+		reflect.Float64: TypeFloat,
+		reflect.Int:     TypeInt,
+		reflect.Bool:    TypeBool,
+	}
+	seen := make(map[Field]bool)
+	body := reflect.TypeOf(Body{})
+	for i := 0; i < body.NumField(); i++ {
+		group := body.Field(i)
+		for j := 0; j < group.Type.NumField(); j++ {
+			sf := group.Type.Field(j)
+			name, _, _ := strings.Cut(sf.Tag.Get("json"), ",")
+			f := Field(name)
+			seen[f] = true
+			info, ok := f.Info()
+			if !ok {
+				t.Errorf("%s.%s (%s) missing from registry", group.Name, sf.Name, f)
+				continue
+			}
+			if want := kinds[sf.Type.Elem().Kind()]; info.Type != want {
+				t.Errorf("%s: Type = %v, want %v", f, info.Type, want)
+			}
+			if info.Section.String() != group.Name {
+				t.Errorf("%s: Section = %v, want %v", f, info.Section, group.Name)
+			}
+			if info.Description == "" {
+				t.Errorf("%s: missing description", f)
+			}
+		}
+	}
+	if len(seen) != len(AllFields()) {
+		t.Errorf("registry has %d fields, Body has %d", len(AllFields()), len(seen))
+	}
+	for _, group := range [][]Field{IdentityFields(), OrbitFields(), UncertaintyFields(), SolutionFields(), NonGravFields(), PhysicalFields()} {
+		for _, f := range group {
+			if _, ok := f.Info(); !ok {
+				t.Errorf("%s missing from registry", f)
+			}
+		}
+	}
+}
+
+func TestField_Info(t *testing.T) {
+	info, ok := MOID.Info()
+	if !ok {
+		t.Fatal("Info() ok = false for MOID")
+	}
+	want := FieldInfo{Field: MOID, Type: TypeFloat, Unit: "au", Description: "Earth minimum orbit intersection distance", Section: SectionIdentity, Constraint: true}
+	if info != want {
+		t.Errorf("Info() = %+v, want %+v", info, want)
+	}
+	if info, ok := EpochCal.Info(); !ok || info.Constraint {
+		t.Errorf("EpochCal.Info() = %+v, %v, want non-filterable entry", info, ok)
+	}
+	if _, ok := Field("bogus").Info(); ok {
+		t.Error("Info() ok = true for unknown field")
+	}
+}
+
+func TestValueType_String(t *testing.T) {
+	tests := []struct {
+		v    ValueType
+		want string
+	}{
+		{TypeFloat, "float"},
+		{TypeBool, "bool"},
+		{0, "Invalid ValueType(0)"},
+	}
+	for _, tt := range tests {
+		if got := tt.v.String(); got != tt.want {
+			t.Errorf("String() = %v, want %v", got, tt.want)
+		}
+	}
+}
+
+func TestSection_String(t *testing.T) {
+	tests := []struct {
+		s    Section
+		want string
+	}{
+		{SectionNonGrav, "NonGrav"},
+		{999, "Invalid Section(999)"},
+	}
+	for _, tt := range tests {
+		if got := tt.s.String(); got != tt.want {
+			t.Errorf("String() = %v, want %v", got, tt.want)
+		}
+	}
+}
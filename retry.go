@@ -0,0 +1,112 @@
+package sbdb
+
+import (
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// DefaultRetryableStatus lists the HTTP status codes retried when
+// RetryPolicy.RetryableStatus is nil.
+var DefaultRetryableStatus = []int{
+	http.StatusTooManyRequests,
+	http.StatusInternalServerError,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// RetryPolicy controls how Client retries failed requests. Transport
+// errors and responses with a retryable status code are retried with
+// exponential backoff and jitter until MaxAttempts is reached or the
+// request context is done. A Retry-After header on the response takes
+// precedence over the computed backoff.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first.
+	// Values below 1 are treated as 1.
+	MaxAttempts int
+	// MinBackoff is the base delay before the first retry. It doubles
+	// on each subsequent attempt.
+	MinBackoff time.Duration
+	// MaxBackoff caps the computed delay between attempts. Zero means
+	// no cap.
+	MaxBackoff time.Duration
+	// RetryableStatus lists the HTTP status codes that trigger a retry.
+	// If nil, DefaultRetryableStatus is used.
+	RetryableStatus []int
+}
+
+// DefaultRetryPolicy returns a RetryPolicy suitable for most clients:
+// four attempts with backoff starting at 500ms and capped at 30s.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts: 4,
+		MinBackoff:  500 * time.Millisecond,
+		MaxBackoff:  30 * time.Second,
+	}
+}
+
+func (p *RetryPolicy) maxAttempts() int {
+	if p == nil || p.MaxAttempts < 1 {
+		return 1
+	}
+	return p.MaxAttempts
+}
+
+func (p *RetryPolicy) retryable(status int) bool {
+	codes := p.RetryableStatus
+	if codes == nil {
+		codes = DefaultRetryableStatus
+	}
+	for _, c := range codes {
+		if c == status {
+			return true
+		}
+	}
+	return false
+}
+
+// backoff returns the delay before the attempt following attempt n
+// (1-based). The delay is drawn uniformly from [d/2, d] where d is the
+// exponential backoff for n, so concurrent clients spread their retries.
+func (p *RetryPolicy) backoff(n int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if d, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+			return d
+		}
+	}
+	d := p.MinBackoff
+	for i := 1; i < n && (p.MaxBackoff <= 0 || d < p.MaxBackoff); i++ {
+		d *= 2
+	}
+	if p.MaxBackoff > 0 && d > p.MaxBackoff {
+		d = p.MaxBackoff
+	}
+	if d <= 0 {
+		return 0
+	}
+	return d/2 + time.Duration(rand.Int63n(int64(d/2)+1))
+}
+
+// parseRetryAfter interprets a Retry-After header value, which may be
+// either a number of seconds or an HTTP date.
+func parseRetryAfter(v string, now time.Time) (time.Duration, bool) {
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		if secs < 0 {
+			return 0, false
+		}
+		return time.Duration(secs) * time.Second, true
+	}
+	t, err := http.ParseTime(v)
+	if err != nil {
+		return 0, false
+	}
+	if d := t.Sub(now); d > 0 {
+		return d, true
+	}
+	return 0, true
+}
@@ -0,0 +1,174 @@
+package sbdb
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestClient_Get_Retry(t *testing.T) {
+	policy := &RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond, MaxBackoff: 5 * time.Millisecond}
+	f := Filter{Fields: NewFieldSet(SpkID), Limit: 1}
+
+	tests := []struct {
+		name         string
+		statuses     []int
+		retry        *RetryPolicy
+		wantErr      bool
+		wantRequests int32
+	}{
+		{
+			name:         "success after 503",
+			statuses:     []int{http.StatusServiceUnavailable, http.StatusOK},
+			retry:        policy,
+			wantRequests: 2,
+		},
+		{
+			name:         "exhausted",
+			statuses:     []int{http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway, http.StatusOK},
+			retry:        policy,
+			wantErr:      true,
+			wantRequests: 3,
+		},
+		{
+			name:         "not retryable",
+			statuses:     []int{http.StatusBadRequest, http.StatusOK},
+			retry:        policy,
+			wantErr:      true,
+			wantRequests: 1,
+		},
+		{
+			name:         "custom retryable status",
+			statuses:     []int{http.StatusBadRequest, http.StatusOK},
+			retry:        &RetryPolicy{MaxAttempts: 2, RetryableStatus: []int{http.StatusBadRequest}},
+			wantRequests: 2,
+		},
+		{
+			name:         "nil policy",
+			statuses:     []int{http.StatusServiceUnavailable, http.StatusOK},
+			wantErr:      true,
+			wantRequests: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				n := atomic.AddInt32(&requests, 1)
+				w.WriteHeader(tt.statuses[n-1])
+			}))
+			defer srv.Close()
+
+			c := &Client{Endpoint: srv.URL, Retry: tt.retry}
+			resp, err := c.Get(context.Background(), f)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Get() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if resp != nil {
+				resp.Body.Close()
+			}
+			if got := atomic.LoadInt32(&requests); got != tt.wantRequests {
+				t.Errorf("requests = %d, want %d", got, tt.wantRequests)
+			}
+		})
+	}
+
+	t.Run("transport error", func(t *testing.T) {
+		var requests int
+		rt := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			requests++
+			if requests == 1 {
+				return nil, errors.New("connection reset")
+			}
+			return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody, Header: make(http.Header), Request: r}, nil
+		})
+		c := &Client{Client: http.Client{Transport: rt}, Endpoint: "http://example.com", Retry: policy}
+		resp, err := c.Get(context.Background(), f)
+		if err != nil {
+			t.Fatalf("Get() error = %v", err)
+		}
+		resp.Body.Close()
+		if requests != 2 {
+			t.Errorf("requests = %d, want 2", requests)
+		}
+	})
+
+	t.Run("context canceled during backoff", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Retry-After", "60")
+			w.WriteHeader(http.StatusTooManyRequests)
+		}))
+		defer srv.Close()
+
+		c := &Client{Endpoint: srv.URL, Retry: policy}
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		start := time.Now()
+		_, err := c.Get(ctx, f)
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("Get() error = %v, want context.DeadlineExceeded", err)
+		}
+		if elapsed := time.Since(start); elapsed > 5*time.Second {
+			t.Errorf("Get() took %v, expected prompt return on cancellation", elapsed)
+		}
+	})
+}
+
+func TestRetryPolicy_backoff(t *testing.T) {
+	p := &RetryPolicy{MinBackoff: 100 * time.Millisecond, MaxBackoff: 300 * time.Millisecond}
+	tests := []struct {
+		name     string
+		attempt  int
+		min, max time.Duration
+	}{
+		{name: "first", attempt: 1, min: 50 * time.Millisecond, max: 100 * time.Millisecond},
+		{name: "second", attempt: 2, min: 100 * time.Millisecond, max: 200 * time.Millisecond},
+		{name: "capped", attempt: 10, min: 150 * time.Millisecond, max: 300 * time.Millisecond},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i := 0; i < 20; i++ {
+				if got := p.backoff(tt.attempt, nil); got < tt.min || got > tt.max {
+					t.Fatalf("backoff(%d) = %v, want in [%v, %v]", tt.attempt, got, tt.min, tt.max)
+				}
+			}
+		})
+	}
+
+	t.Run("Retry-After", func(t *testing.T) {
+		resp := &http.Response{Header: http.Header{"Retry-After": []string{"7"}}}
+		if got := p.backoff(1, resp); got != 7*time.Second {
+			t.Errorf("backoff() = %v, want 7s", got)
+		}
+	})
+}
+
+func Test_parseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		v      string
+		want   time.Duration
+		wantOK bool
+	}{
+		{name: "empty", v: "", wantOK: false},
+		{name: "seconds", v: "120", want: 2 * time.Minute, wantOK: true},
+		{name: "negative", v: "-1", wantOK: false},
+		{name: "http date", v: "Mon, 01 Jan 2024 00:00:30 GMT", want: 30 * time.Second, wantOK: true},
+		{name: "past date", v: "Sun, 31 Dec 2023 23:59:00 GMT", want: 0, wantOK: true},
+		{name: "garbage", v: "soon", wantOK: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := parseRetryAfter(tt.v, now)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("parseRetryAfter(%q) = %v, %v, want %v, %v", tt.v, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
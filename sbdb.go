@@ -4,4 +4,17 @@
 // values. The API itself is documented at
 // https://ssd-api.jpl.nasa.gov/doc/sbdb_query.html and
 // https://ssd-api.jpl.nasa.gov/doc/sbdb_filter.html.
+//
+// Client also covers related SSD APIs:
+//
+//   - Client.Lookup fetches a single body from the SBDB object API,
+//     https://ssd-api.jpl.nasa.gov/doc/sbdb.html.
+//   - Client.CloseApproaches queries the Close-Approach Data API,
+//     https://ssd-api.jpl.nasa.gov/doc/cad.html.
+//   - Client.SentryList and Client.SentryObject query the Sentry impact
+//     risk API, https://ssd-api.jpl.nasa.gov/doc/sentry.html.
+//   - Client.Fireballs queries the Fireball API,
+//     https://ssd-api.jpl.nasa.gov/doc/fireball.html.
+//   - Client.ScoutList and Client.ScoutObject query the Scout NEOCP
+//     hazard assessment API, https://ssd-api.jpl.nasa.gov/doc/scout.html.
 package sbdb
@@ -0,0 +1,381 @@
+package sbdbparquet
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/alanmccallum/sbdb-go"
+	"github.com/google/go-cmp/cmp"
+)
+
+// TestWriter_ReadBack decodes a written file independently of the writer,
+// using the field ids of the Parquet format specification, and checks
+// that every value comes back.
+func TestWriter_ReadBack(t *testing.T) {
+	fields := []sbdb.Field{sbdb.SpkID, sbdb.FullName, sbdb.NEO, sbdb.Eccentricity, sbdb.H}
+	bodies := []sbdb.Body{
+		{Identity: sbdb.Identity{SpkID: ptrTo(2000433), FullName: ptrTo("433 Eros (A898 PA)"), NEO: ptrTo(true)},
+			Orbit: sbdb.Orbit{Eccentricity: ptrTo(0.2229)}, Physical: sbdb.Physical{H: ptrTo(10.39)}},
+		{Identity: sbdb.Identity{SpkID: ptrTo(2000001), NEO: ptrTo(false)}, Orbit: sbdb.Orbit{Eccentricity: ptrTo(0.0785)}},
+		{Identity: sbdb.Identity{SpkID: ptrTo(-1)}},
+		{Identity: sbdb.Identity{SpkID: ptrTo(3), FullName: ptrTo(""), NEO: ptrTo(true)}, Physical: sbdb.Physical{H: ptrTo(-0.5)}},
+		{},
+	}
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, fields)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.RowGroupSize = 2
+	for _, b := range bodies {
+		if err := w.Write(b); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := readParquet(buf.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := make([]sbdb.Record, len(bodies))
+	for i, b := range bodies {
+		r := b.Record()
+		want[i] = make(sbdb.Record, len(fields))
+		for _, f := range fields {
+			want[i][f] = r[f]
+		}
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("read back mismatch (-want +got):\n%s", diff)
+	}
+}
+
+// readParquet decodes a file written by Writer into one record per row,
+// checking the metadata along the way.
+func readParquet(file []byte) ([]sbdb.Record, error) {
+	if len(file) < 12 || string(file[:4]) != magic || string(file[len(file)-4:]) != magic {
+		return nil, errors.New("file is not framed by PAR1")
+	}
+	n := int(binary.LittleEndian.Uint32(file[len(file)-8:]))
+	if n > len(file)-12 {
+		return nil, fmt.Errorf("footer length %d exceeds file", n)
+	}
+	footer := &thriftReader{buf: file[len(file)-8-n : len(file)-8]}
+	fm, err := footer.readStruct()
+	if err != nil {
+		return nil, fmt.Errorf("FileMetaData: %w", err)
+	}
+	if footer.pos != n {
+		return nil, fmt.Errorf("FileMetaData used %d of %d footer bytes", footer.pos, n)
+	}
+	if fm[1] != int64(1) {
+		return nil, fmt.Errorf("version = %v", fm[1])
+	}
+
+	// SchemaElement: 1 type, 3 repetition_type, 4 name, 5 num_children,
+	// 6 converted_type.
+	schema, _ := fm[2].([]any)
+	if len(schema) < 2 {
+		return nil, fmt.Errorf("schema has %d elements", len(schema))
+	}
+	root, _ := schema[0].(map[int16]any)
+	if root[5] != int64(len(schema)-1) {
+		return nil, fmt.Errorf("root num_children = %v, want %d", root[5], len(schema)-1)
+	}
+	type col struct {
+		name string
+		typ  int64
+	}
+	var cols []col
+	for _, e := range schema[1:] {
+		se, _ := e.(map[int16]any)
+		name, err := get[[]byte](se, 4)
+		if err != nil {
+			return nil, fmt.Errorf("SchemaElement: %w", err)
+		}
+		typ, err := get[int64](se, 1)
+		if err != nil {
+			return nil, fmt.Errorf("SchemaElement %s: %w", name, err)
+		}
+		c := col{name: string(name), typ: typ}
+		if se[3] != int64(repetitionOptional) {
+			return nil, fmt.Errorf("column %s repetition = %v", c.name, se[3])
+		}
+		if c.typ == typeByteArray && se[6] != int64(convertedUTF8) {
+			return nil, fmt.Errorf("column %s converted_type = %v", c.name, se[6])
+		}
+		cols = append(cols, c)
+	}
+
+	// RowGroup: 1 columns, 2 total_byte_size, 3 num_rows.
+	var rows []sbdb.Record
+	rowGroups, _ := fm[4].([]any)
+	for gi, g := range rowGroups {
+		rg, _ := g.(map[int16]any)
+		numRows, err := get[int64](rg, 3)
+		if err != nil {
+			return nil, fmt.Errorf("RowGroup %d: %w", gi, err)
+		}
+		chunks, err := get[[]any](rg, 1)
+		if err != nil {
+			return nil, fmt.Errorf("RowGroup %d: %w", gi, err)
+		}
+		if len(chunks) != len(cols) {
+			return nil, fmt.Errorf("row group %d has %d columns", gi, len(chunks))
+		}
+		group := make([]sbdb.Record, numRows)
+		for i := range group {
+			group[i] = make(sbdb.Record, len(cols))
+		}
+		var size int64
+		for ci, ch := range chunks {
+			cc, _ := ch.(map[int16]any)
+			values, chunkSize, err := readChunk(file, cc, cols[ci].name, cols[ci].typ, int(numRows))
+			if err != nil {
+				return nil, fmt.Errorf("row group %d column %s: %w", gi, cols[ci].name, err)
+			}
+			size += chunkSize
+			for i, v := range values {
+				group[i][sbdb.Field(cols[ci].name)] = v
+			}
+		}
+		if rg[2] != size {
+			return nil, fmt.Errorf("row group %d total_byte_size = %v, want %d", gi, rg[2], size)
+		}
+		rows = append(rows, group...)
+	}
+	if fm[3] != int64(len(rows)) {
+		return nil, fmt.Errorf("num_rows = %v, want %d", fm[3], len(rows))
+	}
+	return rows, nil
+}
+
+// readChunk decodes the single data page of a column chunk.
+func readChunk(file []byte, ch map[int16]any, name string, typ int64, numRows int) ([]any, int64, error) {
+	// ColumnChunk: 2 file_offset, 3 meta_data. ColumnMetaData: 1 type,
+	// 2 encodings, 3 path_in_schema, 4 codec, 5 num_values,
+	// 6 total_uncompressed_size, 7 total_compressed_size, 9 data_page_offset.
+	md, err := get[map[int16]any](ch, 3)
+	if err != nil {
+		return nil, 0, err
+	}
+	if md[1] != typ || md[4] != int64(codecUncompressed) || md[5] != int64(numRows) {
+		return nil, 0, fmt.Errorf("metadata type %v, codec %v, num_values %v", md[1], md[4], md[5])
+	}
+	if path, _ := md[3].([]any); len(path) != 1 || fmt.Sprintf("%s", path[0]) != name {
+		return nil, 0, fmt.Errorf("path_in_schema = %q", path)
+	}
+	off, err := get[int64](md, 9)
+	if err != nil {
+		return nil, 0, err
+	}
+	size, err := get[int64](md, 7)
+	if err != nil {
+		return nil, 0, err
+	}
+	if ch[2] != off || md[6] != size || off < 4 || off+size > int64(len(file)) {
+		return nil, 0, fmt.Errorf("chunk at %d with size %d is out of range", off, size)
+	}
+
+	// PageHeader: 1 type, 2 uncompressed_page_size, 3 compressed_page_size,
+	// 5 data_page_header. DataPageHeader: 1 num_values, 2 encoding,
+	// 3 definition_level_encoding.
+	r := &thriftReader{buf: file[off : off+size]}
+	ph, err := r.readStruct()
+	if err != nil {
+		return nil, 0, fmt.Errorf("PageHeader: %w", err)
+	}
+	dph, err := get[map[int16]any](ph, 5)
+	if err != nil {
+		return nil, 0, err
+	}
+	if ph[1] != int64(pageData) || dph[1] != int64(numRows) || dph[2] != int64(encodingPlain) || dph[3] != int64(encodingRLE) {
+		return nil, 0, fmt.Errorf("page header %v", ph)
+	}
+	body := r.buf[r.pos:]
+	if ph[3] != int64(len(body)) || ph[2] != int64(len(body)) {
+		return nil, 0, fmt.Errorf("page size %v, %d bytes follow the header", ph[3], len(body))
+	}
+
+	levelsLen := int(binary.LittleEndian.Uint32(body))
+	defined, err := decodeLevels(body[4:4+levelsLen], numRows)
+	if err != nil {
+		return nil, 0, err
+	}
+	data := body[4+levelsLen:]
+	values := make([]any, numRows)
+	var k int // index of the next non-null value
+	for i, d := range defined {
+		if !d {
+			continue
+		}
+		switch typ {
+		case typeBoolean:
+			values[i] = data[k/8]&(1<<(k%8)) != 0
+		case typeInt64:
+			values[i] = int(int64(binary.LittleEndian.Uint64(data)))
+			data = data[8:]
+		case typeDouble:
+			values[i] = math.Float64frombits(binary.LittleEndian.Uint64(data))
+			data = data[8:]
+		case typeByteArray:
+			n := binary.LittleEndian.Uint32(data)
+			values[i] = string(data[4 : 4+n])
+			data = data[4+n:]
+		default:
+			return nil, 0, fmt.Errorf("unexpected physical type %d", typ)
+		}
+		k++
+	}
+	if typ == typeBoolean {
+		data = data[(k+7)/8:]
+	}
+	if len(data) != 0 {
+		return nil, 0, fmt.Errorf("%d bytes left after values", len(data))
+	}
+	return values, size, nil
+}
+
+// get returns field id of a decoded struct as a T.
+func get[T any](m map[int16]any, id int16) (T, error) {
+	v, ok := m[id].(T)
+	if !ok {
+		return v, fmt.Errorf("field %d = %v, want %T", id, m[id], v)
+	}
+	return v, nil
+}
+
+// decodeLevels decodes n definition levels of bit width 1 encoded with
+// RLE runs.
+func decodeLevels(b []byte, n int) ([]bool, error) {
+	var out []bool
+	for len(b) > 0 {
+		h, k := binary.Uvarint(b)
+		if k <= 0 || h&1 != 0 {
+			return nil, errors.New("expected an RLE run header")
+		}
+		if len(b) < k+1 {
+			return nil, errors.New("truncated RLE run")
+		}
+		for i := uint64(0); i < h>>1; i++ {
+			out = append(out, b[k] == 1)
+		}
+		b = b[k+1:]
+	}
+	if len(out) != n {
+		return nil, fmt.Errorf("decoded %d levels, want %d", len(out), n)
+	}
+	return out, nil
+}
+
+// thriftReader decodes Thrift compact protocol structs into maps from
+// field id to value: int64 for integers, bool, []byte for binary, []any
+// for lists and map[int16]any for structs.
+type thriftReader struct {
+	buf []byte
+	pos int
+}
+
+func (r *thriftReader) byte() (byte, error) {
+	if r.pos >= len(r.buf) {
+		return 0, errors.New("unexpected end of input")
+	}
+	b := r.buf[r.pos]
+	r.pos++
+	return b, nil
+}
+
+func (r *thriftReader) uvarint() (uint64, error) {
+	v, n := binary.Uvarint(r.buf[r.pos:])
+	if n <= 0 {
+		return 0, errors.New("invalid varint")
+	}
+	r.pos += n
+	return v, nil
+}
+
+func (r *thriftReader) varint() (int64, error) {
+	v, err := r.uvarint()
+	return int64(v>>1) ^ -int64(v&1), err
+}
+
+func (r *thriftReader) readStruct() (map[int16]any, error) {
+	out := make(map[int16]any)
+	var last int16
+	for {
+		b, err := r.byte()
+		if err != nil {
+			return nil, err
+		}
+		if b == 0 {
+			return out, nil
+		}
+		id := last + int16(b>>4)
+		if b>>4 == 0 {
+			v, err := r.varint()
+			if err != nil {
+				return nil, err
+			}
+			id = int16(v)
+		}
+		if _, dup := out[id]; dup {
+			return nil, fmt.Errorf("duplicate field %d", id)
+		}
+		typ := b & 0x0f
+		switch typ {
+		case 1, 2: // boolean true, false
+			out[id] = typ == 1
+		default:
+			if out[id], err = r.readValue(typ); err != nil {
+				return nil, fmt.Errorf("field %d: %w", id, err)
+			}
+		}
+		last = id
+	}
+}
+
+func (r *thriftReader) readValue(typ byte) (any, error) {
+	switch typ {
+	case 3: // byte
+		b, err := r.byte()
+		return int64(int8(b)), err
+	case 4, thriftI32, thriftI64:
+		return r.varint()
+	case thriftBinary:
+		n, err := r.uvarint()
+		if err != nil || uint64(len(r.buf)-r.pos) < n {
+			return nil, errors.New("invalid binary length")
+		}
+		b := r.buf[r.pos : r.pos+int(n)]
+		r.pos += int(n)
+		return b, nil
+	case thriftList:
+		h, err := r.byte()
+		if err != nil {
+			return nil, err
+		}
+		n := uint64(h >> 4)
+		if n == 15 {
+			if n, err = r.uvarint(); err != nil {
+				return nil, err
+			}
+		}
+		list := make([]any, n)
+		for i := range list {
+			if list[i], err = r.readValue(h & 0x0f); err != nil {
+				return nil, err
+			}
+		}
+		return list, nil
+	case thriftStruct:
+		return r.readStruct()
+	}
+	return nil, fmt.Errorf("unsupported type %d", typ)
+}
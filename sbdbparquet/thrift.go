@@ -0,0 +1,99 @@
+package sbdbparquet
+
+import (
+	"bytes"
+	"encoding/binary"
+)
+
+// Thrift compact protocol type codes.
+const (
+	thriftI32    = 5
+	thriftI64    = 6
+	thriftBinary = 8
+	thriftList   = 9
+	thriftStruct = 12
+)
+
+// thriftWriter encodes the subset of the Thrift compact protocol needed
+// for Parquet metadata. Structs are written by calling begin, adding
+// fields, and calling end; list elements are written with the elem
+// methods.
+type thriftWriter struct {
+	buf  bytes.Buffer
+	last []int16 // last field id written, per open struct
+}
+
+func (t *thriftWriter) fieldHeader(id int16, typ byte) {
+	n := len(t.last) - 1
+	if delta := id - t.last[n]; delta > 0 && delta <= 15 {
+		t.buf.WriteByte(byte(delta)<<4 | typ)
+	} else {
+		t.buf.WriteByte(typ)
+		t.varint(int64(id))
+	}
+	t.last[n] = id
+}
+
+func (t *thriftWriter) uvarint(v uint64) {
+	t.buf.Write(binary.AppendUvarint(nil, v))
+}
+
+func (t *thriftWriter) varint(v int64) {
+	t.buf.Write(binary.AppendVarint(nil, v))
+}
+
+func (t *thriftWriter) i32(id int16, v int32) {
+	t.fieldHeader(id, thriftI32)
+	t.varint(int64(v))
+}
+
+func (t *thriftWriter) i64(id int16, v int64) {
+	t.fieldHeader(id, thriftI64)
+	t.varint(v)
+}
+
+func (t *thriftWriter) binary(id int16, b []byte) {
+	t.fieldHeader(id, thriftBinary)
+	t.elemBinary(b)
+}
+
+func (t *thriftWriter) string(id int16, s string) {
+	t.binary(id, []byte(s))
+}
+
+// list writes a list header; the caller then writes n elements.
+func (t *thriftWriter) list(id int16, elemType byte, n int) {
+	t.fieldHeader(id, thriftList)
+	if n < 15 {
+		t.buf.WriteByte(byte(n)<<4 | elemType)
+		return
+	}
+	t.buf.WriteByte(0xf0 | elemType)
+	t.uvarint(uint64(n))
+}
+
+// field begins a struct-typed field.
+func (t *thriftWriter) field(id int16) {
+	t.fieldHeader(id, thriftStruct)
+	t.begin()
+}
+
+// begin opens a struct: the top-level message or a list element.
+func (t *thriftWriter) begin() {
+	t.last = append(t.last, 0)
+}
+
+// end writes the stop byte of the innermost open struct.
+func (t *thriftWriter) end() {
+	t.buf.WriteByte(0)
+	t.last = t.last[:len(t.last)-1]
+}
+
+func (t *thriftWriter) elemI32(v int32) {
+	t.varint(int64(v))
+}
+
+func (t *thriftWriter) elemBinary(b []byte) {
+	t.uvarint(uint64(len(b)))
+	t.buf.Write(b)
+}
@@ -0,0 +1,396 @@
+// Package sbdbparquet writes decoded SBDB bodies to Apache Parquet files
+// that can be queried directly by tools such as DuckDB and Spark:
+//
+//	w, err := sbdbparquet.NewWriter(f, sbdb.AllFields())
+//	if err != nil {
+//		return err
+//	}
+//	if err := w.WriteBodies(it); err != nil {
+//		return err
+//	}
+//	return w.Close()
+//
+// The schema has one nullable column per field, typed from the field
+// registry: DOUBLE for floats, INT64 for integers, BOOLEAN for flags and
+// UTF-8 BYTE_ARRAY for strings. Pages are PLAIN encoded and uncompressed.
+package sbdbparquet
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/alanmccallum/sbdb-go"
+)
+
+// DefaultRowGroupSize is the number of rows per row group used when
+// Writer.RowGroupSize is zero.
+const DefaultRowGroupSize = 64 * 1024
+
+// Parquet physical types, repetition types and enums.
+const (
+	typeBoolean   = 0
+	typeInt64     = 2
+	typeDouble    = 5
+	typeByteArray = 6
+
+	repetitionRequired = 0
+	repetitionOptional = 1
+
+	convertedUTF8 = 0
+
+	encodingPlain = 0
+	encodingRLE   = 3
+
+	codecUncompressed = 0
+	pageData          = 0
+)
+
+const magic = "PAR1"
+
+// Writer writes Bodies to a Parquet file. Rows are buffered in memory and
+// written out one row group at a time; Close must be called to write the
+// last row group and the file footer.
+//
+// A Writer is not safe for concurrent use.
+type Writer struct {
+	// RowGroupSize is the maximum number of rows per row group. Larger
+	// row groups compress and scan better but use more memory while
+	// writing. It must be set before the first call to Write; zero means
+	// DefaultRowGroupSize.
+	RowGroupSize int
+
+	w         *countingWriter
+	columns   []*column
+	rows      int
+	rowGroups []rowGroup
+	numRows   int64
+	closed    bool
+}
+
+// column buffers the values of one field for the current row group.
+type column struct {
+	info    sbdb.FieldInfo
+	defined []bool // one per row; false for null
+	bools   []bool
+	ints    []int64
+	floats  []float64
+	strings []string
+}
+
+type rowGroup struct {
+	numRows   int64
+	totalSize int64
+	chunks    []columnChunk
+}
+
+type columnChunk struct {
+	numValues  int64
+	size       int64
+	pageOffset int64
+}
+
+// NewWriter returns a Writer writing a Parquet file with one column per
+// field, in the given order. It returns an error if a field is not in the
+// sbdb field registry.
+func NewWriter(w io.Writer, fields []sbdb.Field) (*Writer, error) {
+	if len(fields) == 0 {
+		return nil, errors.New("must provide at least one field")
+	}
+	pw := &Writer{w: &countingWriter{w: bufio.NewWriter(w)}}
+	seen := make(map[sbdb.Field]bool, len(fields))
+	for _, f := range fields {
+		info, ok := f.Info()
+		if !ok {
+			return nil, fmt.Errorf("unknown field %q", f)
+		}
+		if seen[f] {
+			return nil, fmt.Errorf("duplicate field %q", f)
+		}
+		seen[f] = true
+		pw.columns = append(pw.columns, &column{info: info})
+	}
+	return pw, nil
+}
+
+// Write adds b as a row. Fields that are unset in b are null.
+func (w *Writer) Write(b sbdb.Body) error {
+	if w.closed {
+		return errors.New("write to closed writer")
+	}
+	r := b.Record()
+	for _, c := range w.columns {
+		c.add(r[c.info.Field])
+	}
+	w.rows++
+	if w.rows >= w.rowGroupSize() {
+		return w.flushRowGroup()
+	}
+	return nil
+}
+
+// WritePayload adds every body in p.
+func (w *Writer) WritePayload(p *sbdb.Payload) error {
+	bodies, err := p.Bodies()
+	if err != nil {
+		return err
+	}
+	for _, b := range bodies {
+		if err := w.Write(b); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteBodies adds every Body from src, returning src's error if it
+// stops early.
+func (w *Writer) WriteBodies(src sbdb.BodySource) error {
+	for src.Next() {
+		if err := w.Write(src.Body()); err != nil {
+			return err
+		}
+	}
+	return src.Err()
+}
+
+// Close writes any buffered rows and the file footer. It does not close
+// the underlying writer.
+func (w *Writer) Close() error {
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+	if err := w.flushRowGroup(); err != nil {
+		return err
+	}
+	if w.w.n == 0 {
+		if _, err := io.WriteString(w.w, magic); err != nil {
+			return err
+		}
+	}
+	footer := w.footer()
+	if _, err := w.w.Write(footer); err != nil {
+		return err
+	}
+	if err := binary.Write(w.w, binary.LittleEndian, uint32(len(footer))); err != nil {
+		return err
+	}
+	if _, err := io.WriteString(w.w, magic); err != nil {
+		return err
+	}
+	return w.w.w.Flush()
+}
+
+func (w *Writer) rowGroupSize() int {
+	if w.RowGroupSize > 0 {
+		return w.RowGroupSize
+	}
+	return DefaultRowGroupSize
+}
+
+func (c *column) add(v any) {
+	if v == nil {
+		c.defined = append(c.defined, false)
+		return
+	}
+	c.defined = append(c.defined, true)
+	switch c.info.Type {
+	case sbdb.TypeBool:
+		c.bools = append(c.bools, v.(bool))
+	case sbdb.TypeInt:
+		c.ints = append(c.ints, int64(v.(int)))
+	case sbdb.TypeFloat:
+		c.floats = append(c.floats, v.(float64))
+	default:
+		c.strings = append(c.strings, v.(string))
+	}
+}
+
+func (c *column) reset() {
+	c.defined = c.defined[:0]
+	c.bools = c.bools[:0]
+	c.ints = c.ints[:0]
+	c.floats = c.floats[:0]
+	c.strings = c.strings[:0]
+}
+
+func (w *Writer) flushRowGroup() error {
+	if w.rows == 0 {
+		return nil
+	}
+	if w.w.n == 0 {
+		if _, err := io.WriteString(w.w, magic); err != nil {
+			return err
+		}
+	}
+	rg := rowGroup{numRows: int64(w.rows)}
+	for _, c := range w.columns {
+		data := c.page()
+		var t thriftWriter
+		t.begin()
+		t.i32(1, pageData)
+		t.i32(2, int32(len(data)))
+		t.i32(3, int32(len(data)))
+		t.field(5)
+		t.i32(1, int32(w.rows))
+		t.i32(2, encodingPlain)
+		t.i32(3, encodingRLE)
+		t.i32(4, encodingRLE)
+		t.end()
+		t.end()
+
+		chunk := columnChunk{numValues: int64(w.rows), pageOffset: w.w.n}
+		if _, err := w.w.Write(t.buf.Bytes()); err != nil {
+			return err
+		}
+		if _, err := w.w.Write(data); err != nil {
+			return err
+		}
+		chunk.size = w.w.n - chunk.pageOffset
+		rg.totalSize += chunk.size
+		rg.chunks = append(rg.chunks, chunk)
+		c.reset()
+	}
+	w.rowGroups = append(w.rowGroups, rg)
+	w.numRows += rg.numRows
+	w.rows = 0
+	return nil
+}
+
+// page encodes the buffered column as the body of a v1 data page: the
+// definition levels followed by the PLAIN-encoded non-null values.
+func (c *column) page() []byte {
+	levels := encodeLevels(c.defined)
+	out := binary.LittleEndian.AppendUint32(nil, uint32(len(levels)))
+	out = append(out, levels...)
+	switch c.info.Type {
+	case sbdb.TypeBool:
+		packed := make([]byte, (len(c.bools)+7)/8)
+		for i, b := range c.bools {
+			if b {
+				packed[i/8] |= 1 << (i % 8)
+			}
+		}
+		out = append(out, packed...)
+	case sbdb.TypeInt:
+		for _, v := range c.ints {
+			out = binary.LittleEndian.AppendUint64(out, uint64(v))
+		}
+	case sbdb.TypeFloat:
+		for _, v := range c.floats {
+			out = binary.LittleEndian.AppendUint64(out, math.Float64bits(v))
+		}
+	default:
+		for _, v := range c.strings {
+			out = binary.LittleEndian.AppendUint32(out, uint32(len(v)))
+			out = append(out, v...)
+		}
+	}
+	return out
+}
+
+// encodeLevels encodes definition levels with a maximum level of 1 using
+// the RLE runs of the RLE/bit-packing hybrid encoding.
+func encodeLevels(defined []bool) []byte {
+	var out []byte
+	for i := 0; i < len(defined); {
+		j := i + 1
+		for j < len(defined) && defined[j] == defined[i] {
+			j++
+		}
+		out = binary.AppendUvarint(out, uint64(j-i)<<1)
+		if defined[i] {
+			out = append(out, 1)
+		} else {
+			out = append(out, 0)
+		}
+		i = j
+	}
+	return out
+}
+
+// footer encodes the FileMetaData.
+func (w *Writer) footer() []byte {
+	var t thriftWriter
+	t.begin()
+	t.i32(1, 1)
+
+	t.list(2, thriftStruct, len(w.columns)+1)
+	t.begin()
+	t.i32(3, repetitionRequired)
+	t.string(4, "schema")
+	t.i32(5, int32(len(w.columns)))
+	t.end()
+	for _, c := range w.columns {
+		t.begin()
+		t.i32(1, physicalType(c.info.Type))
+		t.i32(3, repetitionOptional)
+		t.string(4, c.info.Field.String())
+		if c.info.Type == sbdb.TypeString {
+			t.i32(6, convertedUTF8)
+		}
+		t.end()
+	}
+
+	t.i64(3, w.numRows)
+
+	t.list(4, thriftStruct, len(w.rowGroups))
+	for _, rg := range w.rowGroups {
+		t.begin()
+		t.list(1, thriftStruct, len(rg.chunks))
+		for i, ch := range rg.chunks {
+			c := w.columns[i]
+			t.begin()
+			t.i64(2, ch.pageOffset)
+			t.field(3)
+			t.i32(1, physicalType(c.info.Type))
+			t.list(2, thriftI32, 2)
+			t.elemI32(encodingPlain)
+			t.elemI32(encodingRLE)
+			t.list(3, thriftBinary, 1)
+			t.elemBinary([]byte(c.info.Field.String()))
+			t.i32(4, codecUncompressed)
+			t.i64(5, ch.numValues)
+			t.i64(6, ch.size)
+			t.i64(7, ch.size)
+			t.i64(9, ch.pageOffset)
+			t.end()
+			t.end()
+		}
+		t.i64(2, rg.totalSize)
+		t.i64(3, rg.numRows)
+		t.end()
+	}
+
+	t.string(6, "sbdb-go")
+	t.end()
+	return t.buf.Bytes()
+}
+
+func physicalType(t sbdb.ValueType) int32 {
+	switch t {
+	case sbdb.TypeBool:
+		return typeBoolean
+	case sbdb.TypeInt:
+		return typeInt64
+	case sbdb.TypeFloat:
+		return typeDouble
+	}
+	return typeByteArray
+}
+
+// countingWriter tracks the file offset needed for the footer.
+type countingWriter struct {
+	w *bufio.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
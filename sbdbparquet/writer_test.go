@@ -0,0 +1,152 @@
+package sbdbparquet
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/alanmccallum/sbdb-go"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestNewWriter(t *testing.T) {
+	tests := []struct {
+		name    string
+		fields  []sbdb.Field
+		wantErr bool
+	}{
+		{"valid", []sbdb.Field{sbdb.SpkID, sbdb.Eccentricity}, false},
+		{"no fields", nil, true},
+		{"unknown field", []sbdb.Field{"bogus"}, true},
+		{"duplicate field", []sbdb.Field{sbdb.SpkID, sbdb.SpkID}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewWriter(&bytes.Buffer{}, tt.fields)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewWriter() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWriter_Layout(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, []sbdb.Field{sbdb.SpkID, sbdb.NEO, sbdb.Eccentricity, sbdb.FullName})
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.RowGroupSize = 2
+	bodies := []sbdb.Body{
+		{Identity: sbdb.Identity{SpkID: ptrTo(1), NEO: ptrTo(true), FullName: ptrTo("One")}, Orbit: sbdb.Orbit{Eccentricity: ptrTo(0.5)}},
+		{Identity: sbdb.Identity{SpkID: ptrTo(2)}},
+		{Identity: sbdb.Identity{SpkID: ptrTo(3), NEO: ptrTo(false)}},
+	}
+	for _, b := range bodies {
+		if err := w.Write(b); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Write(bodies[0]); err == nil {
+		t.Error("Write() after Close error = nil, want error")
+	}
+
+	out := buf.Bytes()
+	if string(out[:4]) != magic || string(out[len(out)-4:]) != magic {
+		t.Fatalf("file is not framed by %q", magic)
+	}
+	footerLen := int(binary.LittleEndian.Uint32(out[len(out)-8:]))
+	if footerLen <= 0 || footerLen > len(out)-12 {
+		t.Fatalf("footer length = %d, file length %d", footerLen, len(out))
+	}
+	if got, want := len(w.rowGroups), 2; got != want {
+		t.Errorf("row groups = %d, want %d", got, want)
+	}
+	if got, want := w.numRows, int64(3); got != want {
+		t.Errorf("rows = %d, want %d", got, want)
+	}
+	for i, rg := range w.rowGroups {
+		for j, ch := range rg.chunks {
+			if ch.pageOffset < 4 || ch.pageOffset+ch.size > int64(len(out)-8-footerLen) {
+				t.Errorf("row group %d chunk %d spans [%d, %d), outside data", i, j, ch.pageOffset, ch.pageOffset+ch.size)
+			}
+		}
+	}
+}
+
+func TestWriter_Empty(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, []sbdb.Field{sbdb.SpkID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.Bytes()
+	if string(out[:4]) != magic || string(out[len(out)-4:]) != magic {
+		t.Errorf("file is not framed by %q: %q", magic, out)
+	}
+}
+
+func TestColumn_page(t *testing.T) {
+	tests := []struct {
+		name   string
+		typ    sbdb.ValueType
+		values []any
+		want   []byte
+	}{
+		{
+			name:   "bool",
+			typ:    sbdb.TypeBool,
+			values: []any{true, nil, false, true},
+			// levels: run of 1 defined, 1 null, 2 defined; values bit-packed 0b101
+			want: []byte{6, 0, 0, 0, 2, 1, 2, 0, 4, 1, 0b101},
+		},
+		{
+			name:   "int",
+			typ:    sbdb.TypeInt,
+			values: []any{nil, nil, 7},
+			want:   []byte{4, 0, 0, 0, 4, 0, 2, 1, 7, 0, 0, 0, 0, 0, 0, 0},
+		},
+		{
+			name:   "string",
+			typ:    sbdb.TypeString,
+			values: []any{"ab"},
+			want:   []byte{2, 0, 0, 0, 2, 1, 2, 0, 0, 0, 'a', 'b'},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &column{info: sbdb.FieldInfo{Type: tt.typ}}
+			for _, v := range tt.values {
+				c.add(v)
+			}
+			if diff := cmp.Diff(tt.want, c.page()); diff != "" {
+				t.Errorf("page() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestThriftWriter(t *testing.T) {
+	var tw thriftWriter
+	tw.begin()
+	tw.i32(1, 3)   // delta 1, zigzag 6
+	tw.i64(20, -1) // long form: type, zigzag id 40, zigzag 1
+	tw.string(21, "x")
+	tw.list(22, thriftI32, 1)
+	tw.elemI32(2)
+	tw.end()
+	want := []byte{0x15, 6, 0x06, 40, 1, 0x18, 1, 'x', 0x19, 0x15, 4, 0}
+	if diff := cmp.Diff(want, tw.buf.Bytes()); diff != "" {
+		t.Errorf("thriftWriter mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func ptrTo[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,4 @@
+// Package sqlitetest runs the sbdbsqlite store against a real SQLite
+// driver. It is a separate module so that the driver is not a dependency
+// of sbdb-go itself; run its tests from this directory with go test.
+package sqlitetest
@@ -0,0 +1,139 @@
+package sqlitetest
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	"github.com/alanmccallum/sbdb-go"
+	"github.com/alanmccallum/sbdb-go/sbdbsqlite"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	_ "modernc.org/sqlite"
+)
+
+func TestStore_SQLite(t *testing.T) {
+	ctx := context.Background()
+	db, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "sbdb.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	store, err := sbdbsqlite.New(ctx, db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig := sbdb.Signature{Source: "NASA/JPL Small-Body Database (SBDB) Query API", Version: "1.0"}
+	first := sbdb.NewPayload([]sbdb.Field{sbdb.SpkID, sbdb.FullName, sbdb.NEO, sbdb.Eccentricity, sbdb.H}, []sbdb.Record{
+		{sbdb.SpkID: 2000433, sbdb.FullName: "433 Eros (A898 PA)", sbdb.NEO: true, sbdb.Eccentricity: 0.2229, sbdb.H: 10.39},
+		{sbdb.SpkID: 2000001, sbdb.FullName: "1 Ceres (A801 AA)", sbdb.NEO: false, sbdb.Eccentricity: 0.0785},
+	})
+	first.Signature = sig
+	if _, err := store.ImportPayload(ctx, first, "all"); err != nil {
+		t.Fatal(err)
+	}
+	second := sbdb.NewPayload([]sbdb.Field{sbdb.SpkID, sbdb.Eccentricity}, []sbdb.Record{
+		{sbdb.SpkID: 2000433, sbdb.Eccentricity: 0.5},
+		{sbdb.SpkID: 2000004, sbdb.Eccentricity: 0.09},
+	})
+	second.Signature = sig
+	if _, err := store.ImportPayload(ctx, second, "e only"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Creating the schema again must leave the data in place.
+	store, err = sbdbsqlite.New(ctx, db)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type identityRow struct {
+		SpkID    int64
+		Snapshot int64
+		FullName *string
+		NEO      *int64
+	}
+	var identity []identityRow
+	rows, err := db.QueryContext(ctx, `SELECT spkid, snapshot_id, "full_name", "neo" FROM identity ORDER BY spkid`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for rows.Next() {
+		var r identityRow
+		if err := rows.Scan(&r.SpkID, &r.Snapshot, &r.FullName, &r.NEO); err != nil {
+			t.Fatal(err)
+		}
+		identity = append(identity, r)
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatal(err)
+	}
+	wantIdentity := []identityRow{
+		{SpkID: 2000001, Snapshot: 1, FullName: ptrTo("1 Ceres (A801 AA)"), NEO: ptrTo[int64](0)},
+		{SpkID: 2000004, Snapshot: 2},
+		{SpkID: 2000433, Snapshot: 2, FullName: ptrTo("433 Eros (A898 PA)"), NEO: ptrTo[int64](1)},
+	}
+	if diff := cmp.Diff(wantIdentity, identity); diff != "" {
+		t.Errorf("identity rows mismatch (-want +got):\n%s", diff)
+	}
+
+	type orbitRow struct {
+		SpkID    int64
+		Snapshot int64
+		E        *float64
+	}
+	var orbit []orbitRow
+	rows, err = db.QueryContext(ctx, `SELECT spkid, snapshot_id, "e" FROM orbit ORDER BY spkid`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for rows.Next() {
+		var r orbitRow
+		if err := rows.Scan(&r.SpkID, &r.Snapshot, &r.E); err != nil {
+			t.Fatal(err)
+		}
+		orbit = append(orbit, r)
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatal(err)
+	}
+	wantOrbit := []orbitRow{
+		{SpkID: 2000001, Snapshot: 1, E: ptrTo(0.0785)},
+		{SpkID: 2000004, Snapshot: 2, E: ptrTo(0.09)},
+		{SpkID: 2000433, Snapshot: 2, E: ptrTo(0.5)},
+	}
+	if diff := cmp.Diff(wantOrbit, orbit); diff != "" {
+		t.Errorf("orbit rows mismatch (-want +got):\n%s", diff)
+	}
+
+	var h *float64
+	if err := db.QueryRowContext(ctx, `SELECT "H" FROM physical WHERE spkid = 2000433`).Scan(&h); err != nil {
+		t.Fatal(err)
+	}
+	if h == nil || *h != 10.39 {
+		t.Errorf("H of 2000433 = %v, want 10.39 kept from the first import", h)
+	}
+
+	snaps, err := store.Snapshots(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantSnaps := []sbdbsqlite.Snapshot{
+		{ID: 1, Signature: sig, Query: "all", Fields: []sbdb.Field{sbdb.SpkID, sbdb.FullName, sbdb.NEO, sbdb.Eccentricity, sbdb.H}, Count: 2},
+		{ID: 2, Signature: sig, Query: "e only", Fields: []sbdb.Field{sbdb.SpkID, sbdb.Eccentricity}, Count: 2},
+	}
+	if diff := cmp.Diff(wantSnaps, snaps, cmpopts.IgnoreFields(sbdbsqlite.Snapshot{}, "CreatedAt")); diff != "" {
+		t.Errorf("Snapshots() mismatch (-want +got):\n%s", diff)
+	}
+	for _, s := range snaps {
+		if s.CreatedAt.IsZero() {
+			t.Errorf("snapshot %d has no CreatedAt", s.ID)
+		}
+	}
+}
+
+func ptrTo[T any](v T) *T {
+	return &v
+}
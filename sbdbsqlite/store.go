@@ -0,0 +1,328 @@
+// Package sbdbsqlite mirrors SBDB bodies into a SQLite database.
+//
+// The package works with any database/sql SQLite driver, such as
+// modernc.org/sqlite or github.com/mattn/go-sqlite3; import the driver and
+// pass the opened *sql.DB to New:
+//
+//	db, err := sql.Open("sqlite", "sbdb.db")
+//	if err != nil {
+//		return err
+//	}
+//	store, err := sbdbsqlite.New(ctx, db)
+//	if err != nil {
+//		return err
+//	}
+//	snap, err := store.ImportPayload(ctx, p, u.String())
+//
+// Each Body section (Identity, Orbit, Uncertainty, Solution, Quality,
+// NonGrav and Physical) is stored in its own table keyed by spkid, with
+// one column per field in the sbdb field registry. Every import is
+// recorded in the snapshots table with the signature, query and fields
+// that produced it, and each row references the snapshot that last wrote
+// it.
+//
+// The tests in the sqlitetest module run the store against
+// modernc.org/sqlite.
+package sbdbsqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/alanmccallum/sbdb-go"
+)
+
+// Snapshot describes one import into a Store.
+type Snapshot struct {
+	ID        int64
+	Signature sbdb.Signature // Signature of the payload that was imported
+	Query     string         // Query that produced the data, e.g. the request URL
+	// Fields lists the fields that were imported. Columns for other
+	// fields are left untouched on re-import. Empty means all fields.
+	Fields    []sbdb.Field
+	Count     int64 // Number of bodies imported
+	CreatedAt time.Time
+}
+
+// Store is a SQLite mirror of the SBDB catalog. It is safe for concurrent
+// use to the extent the underlying *sql.DB is.
+type Store struct {
+	db *sql.DB
+}
+
+// table is the SQLite table holding one Body section.
+type table struct {
+	name    string
+	section sbdb.Section
+	fields  []sbdb.FieldInfo // excluding spkid
+}
+
+var tables = newTables()
+
+func newTables() []table {
+	names := []struct {
+		name    string
+		section sbdb.Section
+	}{
+		{"identity", sbdb.SectionIdentity},
+		{"orbit", sbdb.SectionOrbit},
+		{"uncertainty", sbdb.SectionUncertainty},
+		{"solution", sbdb.SectionSolution},
+		{"quality", sbdb.SectionQuality},
+		{"non_grav", sbdb.SectionNonGrav},
+		{"physical", sbdb.SectionPhysical},
+	}
+	out := make([]table, len(names))
+	for i, n := range names {
+		out[i] = table{name: n.name, section: n.section}
+	}
+	for _, info := range sbdb.Fields() {
+		if info.Field == sbdb.SpkID {
+			continue
+		}
+		for i := range out {
+			if out[i].section == info.Section {
+				out[i].fields = append(out[i].fields, info)
+			}
+		}
+	}
+	return out
+}
+
+// New returns a Store backed by db, creating the schema if it does not
+// exist.
+func New(ctx context.Context, db *sql.DB) (*Store, error) {
+	s := &Store{db: db}
+	if err := s.createSchema(ctx); err != nil {
+		return nil, fmt.Errorf("create schema failed: %w", err)
+	}
+	return s, nil
+}
+
+func (s *Store) createSchema(ctx context.Context) error {
+	stmts := []string{`CREATE TABLE IF NOT EXISTS snapshots (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	created_at TEXT NOT NULL,
+	signature_source TEXT NOT NULL,
+	signature_version TEXT NOT NULL,
+	query TEXT NOT NULL,
+	fields TEXT NOT NULL,
+	count INTEGER NOT NULL
+)`}
+	for _, t := range tables {
+		var b strings.Builder
+		fmt.Fprintf(&b, "CREATE TABLE IF NOT EXISTS %s (\n\tspkid INTEGER PRIMARY KEY,\n\tsnapshot_id INTEGER NOT NULL REFERENCES snapshots(id)", t.name)
+		for _, f := range t.fields {
+			fmt.Fprintf(&b, ",\n\t%s %s", quote(f.Field.String()), columnType(f.Type))
+		}
+		b.WriteString("\n)")
+		stmts = append(stmts, b.String())
+	}
+	for _, stmt := range stmts {
+		if _, err := s.db.ExecContext(ctx, stmt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ImportPayload imports every body in p, recording query as the query that
+// produced it. Only the payload's fields are written.
+func (s *Store) ImportPayload(ctx context.Context, p *sbdb.Payload, query string) (Snapshot, error) {
+	bodies, err := p.Bodies()
+	if err != nil {
+		return Snapshot{}, err
+	}
+	fields := make([]sbdb.Field, len(p.Fields))
+	for i, f := range p.Fields {
+		fields[i] = sbdb.Field(f)
+	}
+	return s.Import(ctx, &sliceSource{bodies: bodies}, Snapshot{
+		Signature: p.Signature,
+		Query:     query,
+		Fields:    fields,
+	})
+}
+
+// Import imports every Body from src in a single transaction, inserting
+// new bodies and updating existing ones by spkid. The Signature, Query and
+// Fields of snap are recorded; its ID, Count and CreatedAt are filled in
+// and the completed Snapshot is returned. If src fails or a body has no
+// spkid, nothing is imported.
+func (s *Store) Import(ctx context.Context, src sbdb.BodySource, snap Snapshot) (Snapshot, error) {
+	include := make(map[sbdb.Field]bool, len(snap.Fields))
+	for _, f := range snap.Fields {
+		include[f] = true
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return Snapshot{}, err
+	}
+	defer tx.Rollback()
+
+	snap.CreatedAt = time.Now().UTC().Truncate(time.Second)
+	res, err := tx.ExecContext(ctx,
+		"INSERT INTO snapshots (created_at, signature_source, signature_version, query, fields, count) VALUES (?, ?, ?, ?, ?, 0)",
+		snap.CreatedAt.Format(time.RFC3339), snap.Signature.Source, snap.Signature.Version, snap.Query, joinFields(snap.Fields))
+	if err != nil {
+		return Snapshot{}, err
+	}
+	if snap.ID, err = res.LastInsertId(); err != nil {
+		return Snapshot{}, err
+	}
+
+	var upserts []*upsert
+	for _, t := range tables {
+		u, err := prepareUpsert(ctx, tx, t, include)
+		if err != nil {
+			return Snapshot{}, err
+		}
+		defer u.stmt.Close()
+		upserts = append(upserts, u)
+	}
+
+	snap.Count = 0
+	for src.Next() {
+		b := src.Body()
+		if b.Identity.SpkID == nil {
+			return Snapshot{}, fmt.Errorf("body %d has no spkid", snap.Count)
+		}
+		r := b.Record()
+		for _, u := range upserts {
+			if err := u.exec(ctx, snap.ID, r); err != nil {
+				return Snapshot{}, fmt.Errorf("import spkid %d into %s failed: %w", *b.Identity.SpkID, u.table, err)
+			}
+		}
+		snap.Count++
+	}
+	if err := src.Err(); err != nil {
+		return Snapshot{}, err
+	}
+	if _, err := tx.ExecContext(ctx, "UPDATE snapshots SET count = ? WHERE id = ?", snap.Count, snap.ID); err != nil {
+		return Snapshot{}, err
+	}
+	if err := tx.Commit(); err != nil {
+		return Snapshot{}, err
+	}
+	return snap, nil
+}
+
+// upsert is a prepared INSERT ... ON CONFLICT statement for one table.
+type upsert struct {
+	table  string
+	stmt   *sql.Stmt
+	fields []sbdb.Field
+}
+
+func prepareUpsert(ctx context.Context, tx *sql.Tx, t table, include map[sbdb.Field]bool) (*upsert, error) {
+	u := &upsert{table: t.name}
+	cols := []string{"spkid", "snapshot_id"}
+	sets := []string{"snapshot_id = excluded.snapshot_id"}
+	for _, f := range t.fields {
+		if len(include) > 0 && !include[f.Field] {
+			continue
+		}
+		q := quote(f.Field.String())
+		cols = append(cols, q)
+		sets = append(sets, q+" = excluded."+q)
+		u.fields = append(u.fields, f.Field)
+	}
+	query := fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s) ON CONFLICT(spkid) DO UPDATE SET %s",
+		t.name, strings.Join(cols, ", "), strings.TrimSuffix(strings.Repeat("?, ", len(cols)), ", "), strings.Join(sets, ", "))
+	stmt, err := tx.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	u.stmt = stmt
+	return u, nil
+}
+
+func (u *upsert) exec(ctx context.Context, snapshot int64, r sbdb.Record) error {
+	args := make([]any, 0, len(u.fields)+2)
+	args = append(args, r[sbdb.SpkID], snapshot)
+	for _, f := range u.fields {
+		args = append(args, r[f])
+	}
+	_, err := u.stmt.ExecContext(ctx, args...)
+	return err
+}
+
+// Snapshots returns every recorded import, oldest first.
+func (s *Store) Snapshots(ctx context.Context) ([]Snapshot, error) {
+	rows, err := s.db.QueryContext(ctx,
+		"SELECT id, created_at, signature_source, signature_version, query, fields, count FROM snapshots ORDER BY id")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var out []Snapshot
+	for rows.Next() {
+		var snap Snapshot
+		var created, fields string
+		if err := rows.Scan(&snap.ID, &created, &snap.Signature.Source, &snap.Signature.Version, &snap.Query, &fields, &snap.Count); err != nil {
+			return nil, err
+		}
+		if snap.CreatedAt, err = time.Parse(time.RFC3339, created); err != nil {
+			return nil, fmt.Errorf("snapshot %d: %w", snap.ID, err)
+		}
+		snap.Fields = splitFields(fields)
+		out = append(out, snap)
+	}
+	return out, rows.Err()
+}
+
+// sliceSource is a BodySource over a slice.
+type sliceSource struct {
+	bodies []sbdb.Body
+	cur    sbdb.Body
+}
+
+func (s *sliceSource) Next() bool {
+	if len(s.bodies) == 0 {
+		return false
+	}
+	s.cur, s.bodies = s.bodies[0], s.bodies[1:]
+	return true
+}
+
+func (s *sliceSource) Body() sbdb.Body { return s.cur }
+func (s *sliceSource) Err() error      { return nil }
+
+func columnType(t sbdb.ValueType) string {
+	switch t {
+	case sbdb.TypeFloat:
+		return "REAL"
+	case sbdb.TypeInt, sbdb.TypeBool:
+		return "INTEGER"
+	}
+	return "TEXT"
+}
+
+// quote quotes a SQL identifier. Field names are case-sensitive in SBDB
+// but never differ only by case, so they are safe as SQLite columns.
+func quote(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+func joinFields(fields []sbdb.Field) string {
+	names := make([]string, len(fields))
+	for i, f := range fields {
+		names[i] = f.String()
+	}
+	return strings.Join(names, ",")
+}
+
+func splitFields(s string) []sbdb.Field {
+	if s == "" {
+		return nil
+	}
+	var out []sbdb.Field
+	for _, name := range strings.Split(s, ",") {
+		out = append(out, sbdb.Field(name))
+	}
+	return out
+}
@@ -0,0 +1,231 @@
+package sbdbsqlite
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/alanmccallum/sbdb-go"
+	"github.com/google/go-cmp/cmp"
+)
+
+// fakeDriver records the statements executed through it. It understands
+// just enough SQL to answer the snapshots query.
+type fakeDriver struct {
+	mu    sync.Mutex
+	execs []fakeExec
+	rows  [][]driver.Value // returned by any query
+}
+
+type fakeExec struct {
+	query string
+	args  []driver.Value
+}
+
+func (d *fakeDriver) Open(string) (driver.Conn, error) { return &fakeConn{d: d}, nil }
+
+type fakeConn struct{ d *fakeDriver }
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return &fakeStmt{d: c.d, query: query}, nil
+}
+func (c *fakeConn) Close() error              { return nil }
+func (c *fakeConn) Begin() (driver.Tx, error) { return fakeTx{}, nil }
+
+type fakeTx struct{}
+
+func (fakeTx) Commit() error   { return nil }
+func (fakeTx) Rollback() error { return nil }
+
+type fakeStmt struct {
+	d     *fakeDriver
+	query string
+}
+
+func (s *fakeStmt) Close() error  { return nil }
+func (s *fakeStmt) NumInput() int { return -1 }
+
+func (s *fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.d.mu.Lock()
+	defer s.d.mu.Unlock()
+	s.d.execs = append(s.d.execs, fakeExec{query: s.query, args: args})
+	return fakeResult{}, nil
+}
+
+func (s *fakeStmt) Query([]driver.Value) (driver.Rows, error) {
+	return &fakeRows{rows: s.d.rows}, nil
+}
+
+// fakeResult reports 7 as the ID of every inserted row.
+type fakeResult struct{}
+
+func (fakeResult) LastInsertId() (int64, error) { return 7, nil }
+func (fakeResult) RowsAffected() (int64, error) { return 1, nil }
+
+type fakeRows struct{ rows [][]driver.Value }
+
+func (r *fakeRows) Columns() []string {
+	return []string{"id", "created_at", "signature_source", "signature_version", "query", "fields", "count"}
+}
+func (r *fakeRows) Close() error { return nil }
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.rows[0])
+	r.rows = r.rows[1:]
+	return nil
+}
+
+func openFake(t *testing.T) (*sql.DB, *fakeDriver) {
+	t.Helper()
+	d := &fakeDriver{}
+	name := "sbdbsqlite-fake-" + t.Name()
+	sql.Register(name, d)
+	db, err := sql.Open(name, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db, d
+}
+
+func TestNew_Schema(t *testing.T) {
+	db, d := openFake(t)
+	if _, err := New(context.Background(), db); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := len(d.execs), len(tables)+1; got != want {
+		t.Fatalf("executed %d statements, want %d", got, want)
+	}
+	var orbit string
+	for _, e := range d.execs {
+		if strings.HasPrefix(e.query, "CREATE TABLE IF NOT EXISTS orbit ") {
+			orbit = e.query
+		}
+	}
+	for _, want := range []string{`spkid INTEGER PRIMARY KEY`, `"e" REAL`, `"orbit_id" TEXT`, `REFERENCES snapshots(id)`} {
+		if !strings.Contains(orbit, want) {
+			t.Errorf("orbit table %q does not contain %q", orbit, want)
+		}
+	}
+}
+
+func TestTables_CoverRegistry(t *testing.T) {
+	seen := map[sbdb.Field]bool{sbdb.SpkID: true}
+	for _, tb := range tables {
+		for _, f := range tb.fields {
+			if seen[f.Field] {
+				t.Errorf("field %s stored twice", f.Field)
+			}
+			seen[f.Field] = true
+		}
+	}
+	for _, f := range sbdb.AllFields() {
+		if !seen[f] {
+			t.Errorf("field %s not stored", f)
+		}
+	}
+}
+
+func TestStore_ImportPayload(t *testing.T) {
+	db, d := openFake(t)
+	s, err := New(context.Background(), db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d.execs = nil
+
+	p := sbdb.NewPayload([]sbdb.Field{sbdb.SpkID, sbdb.NEO, sbdb.Eccentricity}, []sbdb.Record{
+		{sbdb.SpkID: 2000433, sbdb.NEO: true, sbdb.Eccentricity: 0.22},
+	})
+	p.Signature = sbdb.Signature{Source: "test", Version: "1.0"}
+	snap, err := s.ImportPayload(context.Background(), p, "fields=spkid,neo,e")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if snap.ID != 7 || snap.Count != 1 || snap.CreatedAt.IsZero() {
+		t.Errorf("ImportPayload() = %+v", snap)
+	}
+
+	var identity, orbit, physical *fakeExec
+	for i, e := range d.execs {
+		switch {
+		case strings.HasPrefix(e.query, "INSERT INTO identity "):
+			identity = &d.execs[i]
+		case strings.HasPrefix(e.query, "INSERT INTO orbit "):
+			orbit = &d.execs[i]
+		case strings.HasPrefix(e.query, "INSERT INTO physical "):
+			physical = &d.execs[i]
+		}
+	}
+	if identity == nil || orbit == nil || physical == nil {
+		t.Fatalf("missing upserts in %v", d.execs)
+	}
+	want := `INSERT INTO identity (spkid, snapshot_id, "neo") VALUES (?, ?, ?) ON CONFLICT(spkid) DO UPDATE SET snapshot_id = excluded.snapshot_id, "neo" = excluded."neo"`
+	if identity.query != want {
+		t.Errorf("identity upsert = %s, want %s", identity.query, want)
+	}
+	if diff := cmp.Diff([]driver.Value{int64(2000433), int64(7), true}, identity.args); diff != "" {
+		t.Errorf("identity args mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]driver.Value{int64(2000433), int64(7), 0.22}, orbit.args); diff != "" {
+		t.Errorf("orbit args mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]driver.Value{int64(2000433), int64(7)}, physical.args); diff != "" {
+		t.Errorf("physical args mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestStore_Import_MissingSpkID(t *testing.T) {
+	db, _ := openFake(t)
+	s, err := New(context.Background(), db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	src := &sliceSource{bodies: []sbdb.Body{{}}}
+	if _, err := s.Import(context.Background(), src, Snapshot{}); err == nil {
+		t.Error("Import() error = nil, want error")
+	}
+}
+
+type failingSource struct{ sliceSource }
+
+func (failingSource) Err() error { return errors.New("boom") }
+
+func TestStore_Import_SourceError(t *testing.T) {
+	db, _ := openFake(t)
+	s, err := New(context.Background(), db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Import(context.Background(), &failingSource{}, Snapshot{}); err == nil || err.Error() != "boom" {
+		t.Errorf("Import() error = %v, want boom", err)
+	}
+}
+
+func TestStore_Snapshots(t *testing.T) {
+	db, d := openFake(t)
+	s, err := New(context.Background(), db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d.rows = [][]driver.Value{
+		{int64(1), "2026-01-02T03:04:05Z", "src", "1.0", "q", "spkid,e", int64(10)},
+	}
+	got, err := s.Snapshots(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 {
+		t.Fatalf("Snapshots() returned %d snapshots", len(got))
+	}
+	if got[0].Count != 10 || got[0].CreatedAt.Year() != 2026 || len(got[0].Fields) != 2 || got[0].Fields[1] != sbdb.Eccentricity {
+		t.Errorf("Snapshots() = %+v", got[0])
+	}
+}
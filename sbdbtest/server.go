@@ -0,0 +1,372 @@
+// Package sbdbtest provides an in-process fake of the SBDB Query API for
+// use in tests. Point sbdb.Client.Endpoint at Server.URL to exercise code
+// that queries SBDB without touching the network:
+//
+//	srv := sbdbtest.NewServer(records)
+//	defer srv.Close()
+//	c := &sbdb.Client{Endpoint: srv.URL}
+package sbdbtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/alanmccallum/sbdb-go"
+)
+
+// Signature is the signature reported in every payload served by Server.
+var Signature = sbdb.Signature{
+	Source:  "NASA/JPL Small-Body Database (SBDB) Query API",
+	Version: "1.0",
+}
+
+// Server is a fake SBDB Query API backed by an in-memory dataset. It
+// understands the query parameters emitted by sbdb.Filter.Values and
+// answers invalid requests with JPL-style error payloads.
+type Server struct {
+	*httptest.Server
+
+	records []sbdb.Record
+	known   map[sbdb.Field]bool
+
+	mu       sync.Mutex
+	queries  []url.Values
+	failures []int
+}
+
+// NewServer starts and returns a Server serving records. Fields missing
+// from a record are treated as NULL. The caller should call Close when
+// finished.
+func NewServer(records []sbdb.Record) *Server {
+	s := &Server{records: records, known: make(map[sbdb.Field]bool)}
+	for _, f := range sbdb.AllFields() {
+		s.known[f] = true
+	}
+	s.Server = httptest.NewServer(s)
+	return s
+}
+
+// NewServerBodies starts and returns a Server serving bodies.
+func NewServerBodies(bodies []sbdb.Body) *Server {
+	records := make([]sbdb.Record, len(bodies))
+	for i, b := range bodies {
+		records[i] = b.Record()
+	}
+	return NewServer(records)
+}
+
+// Queries returns the query parameters of every request received so far.
+func (s *Server) Queries() []url.Values {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]url.Values(nil), s.queries...)
+}
+
+// Fail makes the next n requests fail with the given HTTP status code,
+// which is useful for exercising retry logic.
+func (s *Server) Fail(status, n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := 0; i < n; i++ {
+		s.failures = append(s.failures, status)
+	}
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	s.mu.Lock()
+	s.queries = append(s.queries, q)
+	var fail int
+	if len(s.failures) > 0 {
+		fail, s.failures = s.failures[0], s.failures[1:]
+	}
+	s.mu.Unlock()
+
+	if fail != 0 {
+		writeError(w, fail, http.StatusText(fail))
+		return
+	}
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "only GET requests are supported")
+		return
+	}
+	payload, err := s.query(q)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = payload.Encode(w)
+}
+
+func (s *Server) query(q url.Values) (*sbdb.Payload, error) {
+	if q.Get("fields") == "" {
+		return nil, fmt.Errorf("missing required parameter 'fields'")
+	}
+	var fields []sbdb.Field
+	for _, f := range strings.Split(q.Get("fields"), ",") {
+		if !s.known[sbdb.Field(f)] {
+			return nil, fmt.Errorf("invalid field name in 'fields': %s", f)
+		}
+		fields = append(fields, sbdb.Field(f))
+	}
+	limit, err := uintParam(q, "limit")
+	if err != nil {
+		return nil, err
+	}
+	from, err := uintParam(q, "limit-from")
+	if err != nil {
+		return nil, err
+	}
+	filters, err := s.filters(q)
+	if err != nil {
+		return nil, err
+	}
+
+	order, err := s.sortKeys(q)
+	if err != nil {
+		return nil, err
+	}
+
+	var matched []sbdb.Record
+	for _, rec := range s.records {
+		ok, err := matchAll(filters, rec)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			matched = append(matched, rec)
+		}
+	}
+	if len(order) > 0 {
+		sort.SliceStable(matched, func(i, j int) bool {
+			for _, k := range order {
+				if c := compare(matched[i][k.Field], matched[j][k.Field]); c != 0 {
+					return (c < 0) != k.Desc
+				}
+			}
+			return false
+		})
+	}
+	if from > len(matched) {
+		from = len(matched)
+	}
+	matched = matched[from:]
+	if limit > 0 && limit < len(matched) {
+		matched = matched[:limit]
+	}
+
+	p := sbdb.NewPayload(fields, matched)
+	p.Signature = Signature
+	return p, nil
+}
+
+func (s *Server) sortKeys(q url.Values) (sbdb.SortKeys, error) {
+	v := q.Get("sort")
+	if v == "" {
+		return nil, nil
+	}
+	var keys sbdb.SortKeys
+	for _, name := range strings.Split(v, ",") {
+		k := sbdb.Asc(sbdb.Field(name))
+		if strings.HasPrefix(name, "-") {
+			k = sbdb.Desc(sbdb.Field(name[1:]))
+		}
+		if !s.known[k.Field] {
+			return nil, fmt.Errorf("invalid field name in 'sort': %s", k.Field)
+		}
+		keys = append(keys, k)
+	}
+	if len(keys) > 3 {
+		return nil, fmt.Errorf("too many fields in 'sort': %d (max 3)", len(keys))
+	}
+	return keys, nil
+}
+
+// compare orders two record values numerically when both are numbers
+// and as text otherwise. NULL values sort last.
+func compare(a, b any) int {
+	sa, okA := sbdb.FormatValue(a)
+	sb, okB := sbdb.FormatValue(b)
+	switch {
+	case !okA && !okB:
+		return 0
+	case !okA:
+		return 1
+	case !okB:
+		return -1
+	}
+	fa, errA := strconv.ParseFloat(sa, 64)
+	fb, errB := strconv.ParseFloat(sb, 64)
+	if errA == nil && errB == nil {
+		switch {
+		case fa < fb:
+			return -1
+		case fa > fb:
+			return 1
+		}
+		return 0
+	}
+	return strings.Compare(sa, sb)
+}
+
+func uintParam(q url.Values, name string) (int, error) {
+	v := q.Get(name)
+	if v == "" {
+		return 0, nil
+	}
+	n, err := strconv.ParseUint(v, 10, 31)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value for '%s': %s", name, v)
+	}
+	return int(n), nil
+}
+
+// filter reports whether a record passes one of the sb-* parameters.
+type filter func(sbdb.Record) (bool, error)
+
+func matchAll(filters []filter, rec sbdb.Record) (bool, error) {
+	for _, f := range filters {
+		ok, err := f(rec)
+		if err != nil || !ok {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+var (
+	numberedDesignation = regexp.MustCompile(`^[0-9]+[A-Z]?$`)
+	fragmentDesignation = regexp.MustCompile(`-[A-Z]+$`)
+)
+
+func (s *Server) filters(q url.Values) ([]filter, error) {
+	var filters []filter
+	switch v := q.Get("sb-kind"); v {
+	case "":
+	case "a", "c":
+		filters = append(filters, func(r sbdb.Record) (bool, error) {
+			return strings.HasPrefix(text(r, sbdb.Kind), v), nil
+		})
+	default:
+		return nil, fmt.Errorf("invalid value for 'sb-kind': %s", v)
+	}
+	switch v := q.Get("sb-ns"); v {
+	case "":
+	case "n", "u":
+		filters = append(filters, func(r sbdb.Record) (bool, error) {
+			return numberedDesignation.MatchString(text(r, sbdb.PDes)) == (v == "n"), nil
+		})
+	default:
+		return nil, fmt.Errorf("invalid value for 'sb-ns': %s", v)
+	}
+	switch v := q.Get("sb-group"); v {
+	case "":
+	case "neo", "pha":
+		filters = append(filters, func(r sbdb.Record) (bool, error) {
+			return text(r, sbdb.Field(v)) == "Y", nil
+		})
+	default:
+		return nil, fmt.Errorf("invalid value for 'sb-group': %s", v)
+	}
+	if v := q.Get("sb-class"); v != "" {
+		classes := strings.Split(v, ",")
+		if len(classes) > 3 {
+			return nil, fmt.Errorf("too many classes in 'sb-class': %d (max 3)", len(classes))
+		}
+		filters = append(filters, func(r sbdb.Record) (bool, error) {
+			c := text(r, sbdb.Class)
+			for _, want := range classes {
+				if c == want {
+					return true, nil
+				}
+			}
+			return false, nil
+		})
+	}
+	if ok, err := boolParam(q, "sb-sat"); err != nil {
+		return nil, err
+	} else if ok {
+		filters = append(filters, func(r sbdb.Record) (bool, error) {
+			n, err := strconv.Atoi(text(r, sbdb.Sats))
+			return err == nil && n > 0, nil
+		})
+	}
+	if ok, err := boolParam(q, "sb-xfrag"); err != nil {
+		return nil, err
+	} else if ok {
+		filters = append(filters, func(r sbdb.Record) (bool, error) {
+			return !fragmentDesignation.MatchString(text(r, sbdb.PDes)), nil
+		})
+	}
+	for _, name := range []string{"sb-cf", "sb-cdata"} {
+		name := name
+		v := q.Get(name)
+		if v == "" {
+			continue
+		}
+		e, err := sbdb.UnmarshalExpr([]byte(v))
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for '%s': %v", name, err)
+		}
+		filters = append(filters, func(r sbdb.Record) (bool, error) {
+			ok, err := sbdb.Eval(e, s.complete(r))
+			if err != nil {
+				return false, fmt.Errorf("invalid value for '%s': %v", name, err)
+			}
+			return ok, nil
+		})
+	}
+	return filters, nil
+}
+
+func boolParam(q url.Values, name string) (bool, error) {
+	v := q.Get(name)
+	if v == "" {
+		return false, nil
+	}
+	switch strings.ToLower(v) {
+	case "1", "true", "y":
+		return true, nil
+	case "0", "false", "n":
+		return false, nil
+	}
+	return false, fmt.Errorf("invalid value for '%s': %s", name, v)
+}
+
+// complete returns r with every known field present, so constraints on
+// fields the dataset omits evaluate as NULL rather than failing.
+func (s *Server) complete(r sbdb.Record) sbdb.Record {
+	out := make(sbdb.Record, len(s.known))
+	for f := range s.known {
+		out[f] = r[f]
+	}
+	for f, v := range r {
+		out[f] = v
+	}
+	return out
+}
+
+func text(r sbdb.Record, f sbdb.Field) string {
+	s, _ := sbdb.FormatValue(r[f])
+	return s
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]string{
+		"code":     strconv.Itoa(status),
+		"message":  message,
+		"moreInfo": "https://ssd-api.jpl.nasa.gov/doc/sbdb_query.html",
+	})
+}
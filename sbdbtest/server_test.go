@@ -0,0 +1,154 @@
+package sbdbtest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/alanmccallum/sbdb-go"
+	"github.com/google/go-cmp/cmp"
+)
+
+func testRecords() []sbdb.Record {
+	return []sbdb.Record{
+		{sbdb.SpkID: "20000001", sbdb.PDes: "1", sbdb.Kind: "an", sbdb.Class: "MBA", sbdb.NEO: "N", sbdb.PHA: "N", sbdb.Sats: "0", sbdb.Eccentricity: "0.0785"},
+		{sbdb.SpkID: "20000433", sbdb.PDes: "433", sbdb.Kind: "an", sbdb.Class: "AMO", sbdb.NEO: "Y", sbdb.PHA: "N", sbdb.Sats: "0", sbdb.Eccentricity: "0.2228"},
+		{sbdb.SpkID: "20099942", sbdb.PDes: "99942", sbdb.Kind: "an", sbdb.Class: "ATE", sbdb.NEO: "Y", sbdb.PHA: "Y", sbdb.Sats: "0", sbdb.Eccentricity: "0.1915"},
+		{sbdb.SpkID: "20065803", sbdb.PDes: "65803", sbdb.Kind: "an", sbdb.Class: "APO", sbdb.NEO: "Y", sbdb.PHA: "Y", sbdb.Sats: "1", sbdb.Eccentricity: "0.3832"},
+		{sbdb.SpkID: "54509622", sbdb.PDes: "2024 YR4", sbdb.Kind: "au", sbdb.Class: "APO", sbdb.NEO: "Y", sbdb.PHA: "N", sbdb.Sats: "0", sbdb.Eccentricity: "0.6616"},
+		{sbdb.SpkID: "1000012", sbdb.PDes: "1P", sbdb.Kind: "cn", sbdb.Class: "HTC", sbdb.Eccentricity: "0.9679"},
+		{sbdb.SpkID: "1000093", sbdb.PDes: "73P-B", sbdb.Kind: "cn", sbdb.Class: "JFc", sbdb.Eccentricity: "0.6852"},
+	}
+}
+
+func TestServer(t *testing.T) {
+	srv := NewServer(testRecords())
+	defer srv.Close()
+	c := &sbdb.Client{Endpoint: srv.URL}
+
+	tests := []struct {
+		name    string
+		filter  sbdb.Filter
+		want    []int
+		wantErr bool
+	}{
+		{name: "all", filter: sbdb.Filter{}, want: []int{20000001, 20000433, 20099942, 20065803, 54509622, 1000012, 1000093}},
+		{name: "limit", filter: sbdb.Filter{Limit: 2}, want: []int{20000001, 20000433}},
+		{name: "limit from", filter: sbdb.Filter{Limit: 2, LimitFrom: 2}, want: []int{20099942, 20065803}},
+		{name: "sort", filter: sbdb.Filter{Sort: sbdb.SortKeys{sbdb.Desc(sbdb.Eccentricity)}, Limit: 3}, want: []int{1000012, 1000093, 54509622}},
+		{name: "sort multiple", filter: sbdb.Filter{Sort: sbdb.SortKeys{sbdb.Asc(sbdb.Kind), sbdb.Asc(sbdb.Eccentricity)}, Limit: 3}, want: []int{20000001, 20099942, 20000433}},
+		{name: "kind", filter: sbdb.Filter{Kind: sbdb.KindComet}, want: []int{1000012, 1000093}},
+		{name: "numbered", filter: sbdb.Filter{NumberedStatus: sbdb.NumStatusUnnumbered}, want: []int{54509622, 1000093}},
+		{name: "group", filter: sbdb.Filter{Group: sbdb.GroupPHA}, want: []int{20099942, 20065803}},
+		{name: "class", filter: sbdb.Filter{Classes: sbdb.ClassFilters{sbdb.APO, sbdb.ATE}}, want: []int{20099942, 20065803, 54509622}},
+		{name: "satellite", filter: sbdb.Filter{MustHaveSatellite: true}, want: []int{20065803}},
+		{name: "exclude fragments", filter: sbdb.Filter{Kind: sbdb.KindComet, ExcludeFragments: true}, want: []int{1000012}},
+		{
+			name:   "constraints",
+			filter: sbdb.Filter{FieldConstraints: sbdb.Or{sbdb.And{sbdb.GT("e", "0.3"), sbdb.EQ("neo", "Y")}, sbdb.EQ("class", "HTC")}},
+			want:   []int{20065803, 54509622, 1000012},
+		},
+		{
+			name:   "constraint on missing field",
+			filter: sbdb.Filter{FieldConstraints: sbdb.DF("albedo")},
+			want:   nil,
+		},
+		{name: "invalid constraint", filter: sbdb.Filter{FieldConstraints: sbdb.ComparisonExpr("e|XX|1")}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.filter.Fields = sbdb.NewFieldSet(sbdb.SpkID)
+			resp, err := c.Get(context.Background(), tt.filter)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Get() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				var apiErr *sbdb.APIError
+				if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadRequest || apiErr.Message == "" {
+					t.Errorf("Get() error = %#v, want *sbdb.APIError with status 400 and message", err)
+				}
+				return
+			}
+			defer resp.Body.Close()
+			p, err := sbdb.Decode(resp.Body)
+			if err != nil {
+				t.Fatalf("Decode() error = %v", err)
+			}
+			bodies, err := p.Bodies()
+			if err != nil {
+				t.Fatalf("Bodies() error = %v", err)
+			}
+			var got []int
+			for _, b := range bodies {
+				got = append(got, *b.Identity.SpkID)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("spkids mismatch (-want +got):\n%s", diff)
+			}
+			if p.Count != len(got) || p.Signature != Signature {
+				t.Errorf("Count = %d, Signature = %v", p.Count, p.Signature)
+			}
+		})
+	}
+}
+
+func TestServer_errors(t *testing.T) {
+	srv := NewServer(testRecords())
+	defer srv.Close()
+
+	tests := []struct {
+		name  string
+		query string
+	}{
+		{name: "missing fields", query: ""},
+		{name: "unknown field", query: "fields=spkid,bogus"},
+		{name: "bad limit", query: "fields=spkid&limit=x"},
+		{name: "bad kind", query: "fields=spkid&sb-kind=z"},
+		{name: "bad constraint json", query: "fields=spkid&sb-cf={"},
+		{name: "unknown sort field", query: "fields=spkid&sort=-bogus"},
+		{name: "too many classes", query: "fields=spkid&sb-class=APO,ATE,AMO,IEO"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := http.Get(srv.URL + "?" + tt.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp.Body.Close()
+			if resp.StatusCode != http.StatusBadRequest {
+				t.Errorf("status = %d, want 400", resp.StatusCode)
+			}
+		})
+	}
+}
+
+func TestServer_Fail(t *testing.T) {
+	srv := NewServerBodies([]sbdb.Body{{Identity: sbdb.Identity{SpkID: ptrTo(1)}}})
+	defer srv.Close()
+	srv.Fail(http.StatusServiceUnavailable, 2)
+
+	c := &sbdb.Client{
+		Endpoint: srv.URL,
+		Retry:    &sbdb.RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond},
+	}
+	it := c.Iterate(context.Background(), sbdb.Filter{Fields: sbdb.NewFieldSet(sbdb.SpkID)}, 10)
+	var n int
+	for it.Next() {
+		n++
+	}
+	if err := it.Err(); err != nil {
+		t.Fatalf("Err() = %v", err)
+	}
+	if n != 1 {
+		t.Errorf("bodies = %d, want 1", n)
+	}
+	if got := len(srv.Queries()); got != 3 {
+		t.Errorf("requests = %d, want 3", got)
+	}
+}
+
+func ptrTo[T any](v T) *T {
+	return &v
+}
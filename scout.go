@@ -0,0 +1,306 @@
+package sbdb
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// ScoutEndpoint is the default base URL for the Scout NEOCP hazard
+// assessment API. It can be overridden via Client.ScoutEndpoint.
+const ScoutEndpoint = "https://ssd-api.jpl.nasa.gov/scout.api"
+
+// ScoutQuery defines the parameters for the Scout API, documented at
+// https://ssd-api.jpl.nasa.gov/doc/scout.html. The zero value requests
+// the summary list; Designation selects a single object and the optional
+// outputs to include with its summary.
+type ScoutQuery struct {
+	// Designation is the object's temporary NEOCP designation, as
+	// reported in ScoutEntry.Name. It is required by ScoutObject and by
+	// every other option.
+	Designation string
+	// Orbits, when true, includes the sampled orbits and their Earth
+	// encounters in the results.
+	Orbits bool
+	// EphStart, when set, includes an ephemeris starting at this time.
+	// EphStop and EphStep extend it to a table; leave them empty for a
+	// single time.
+	EphStart time.Time
+	EphStop  time.Time
+	EphStep  string // Ephemeris step, e.g. "1h" or "30m"
+	ObsCode  string // MPC observatory code for the ephemeris; defaults to geocentric
+}
+
+// Values converts q into URL query parameters.
+func (q ScoutQuery) Values() (url.Values, error) {
+	if q.Designation == "" && (q.Orbits || !q.EphStart.IsZero()) {
+		return nil, errors.New("object options require Designation")
+	}
+	if q.EphStart.IsZero() && (!q.EphStop.IsZero() || q.EphStep != "" || q.ObsCode != "") {
+		return nil, errors.New("ephemeris options require EphStart")
+	}
+	if err := checkTimeRange("EphStart", q.EphStart, "EphStop", q.EphStop); err != nil {
+		return nil, err
+	}
+
+	v := url.Values{}
+	p := params(v)
+	p.setString("tdes", q.Designation)
+	if q.Orbits {
+		v.Set("orbits", "1")
+	}
+	p.setTime("eph-start", q.EphStart)
+	p.setTime("eph-stop", q.EphStop)
+	p.setString("eph-step", q.EphStep)
+	p.setString("obs-code", q.ObsCode)
+	return v, nil
+}
+
+// ScoutEntry is an object in the Scout summary list: an unconfirmed
+// object on the MPC's NEO Confirmation Page.
+type ScoutEntry struct {
+	Name            string    // Temporary NEOCP designation
+	LastRun         time.Time // Time of the latest Scout analysis (UT)
+	NObs            *int      // Number of observations
+	Arc             *float64  // Observation arc (days)
+	RMSN            *float64  // Normalized RMS of the orbit fit
+	H               *float64  // Absolute magnitude
+	VMag            *float64  // Current visual magnitude
+	RA              string    // Current right ascension, e.g. "03:47"
+	Dec             string    // Current declination, e.g. "+18"
+	Elong           *float64  // Current solar elongation (deg)
+	Rate            *float64  // Current rate of motion (arcsec/min)
+	Unc             *float64  // 1-sigma plane-of-sky uncertainty (arcmin)
+	UncP1           *float64  // 1-sigma plane-of-sky uncertainty one day from now (arcmin)
+	NEOScore        *int      // Likelihood the object is a NEO (0-100)
+	NEO1kmScore     *int      // Likelihood the object is a NEO larger than 1 km (0-100)
+	PHAScore        *int      // Likelihood the object is a PHA (0-100)
+	IEOScore        *int      // Likelihood the object is an Interior Earth Object (0-100)
+	GeocentricScore *int      // Likelihood the object is in a geocentric orbit (0-100)
+	TisserandScore  *int      // Likelihood the object has a comet-like Tisserand parameter (0-100)
+	MOID            *float64  // Earth minimum orbit intersection distance (au)
+	VInf            *float64  // Velocity relative to a massless Earth (km/s)
+	CADist          *float64  // Minimum close-approach distance to Earth (au)
+	Rating          *int      // Impact rating, 0 (negligible) to 4
+	TEphem          time.Time // Time of the current position values (UT)
+}
+
+// ScoutDetail is the Scout assessment of a single object.
+type ScoutDetail struct {
+	ScoutEntry
+	// Orbits lists the sampled orbits, if requested with
+	// ScoutQuery.Orbits.
+	Orbits []ScoutOrbit
+	// Ephemeris lists the predicted positions, if requested with
+	// ScoutQuery.EphStart.
+	Ephemeris []ScoutEphemeris
+}
+
+// ScoutOrbit is one orbit sampled from the range of orbits that fit the
+// observations of a Scout object.
+type ScoutOrbit struct {
+	Index          *int     // Index of the sample
+	Epoch          *float64 // Epoch of osculation (JD)
+	Eccentricity   *float64 // Orbital eccentricity
+	PerihelionDist *float64 // Perihelion distance (au)
+	PeriapsisTime  *float64 // Time of periapsis (JD)
+	AscNode        *float64 // Longitude of ascending node (deg)
+	PeriapsisArg   *float64 // Argument of periapsis (deg)
+	Inclination    *float64 // Inclination (deg)
+	H              *float64 // Absolute magnitude
+	Encounter      ScoutEncounter
+}
+
+// ScoutEncounter is the closest Earth encounter of a sampled orbit.
+type ScoutEncounter struct {
+	Dist    *float64 // Close-approach distance (Earth radii)
+	JD      *float64 // Time of close approach (JD, TDB)
+	MOID    *float64 // Earth minimum orbit intersection distance (au)
+	VInf    *float64 // Velocity relative to a massless Earth (km/s)
+	GeoEcc  *float64 // Geocentric eccentricity
+	ImpFlag *int     // Non-zero if the orbit impacts Earth
+}
+
+// ScoutEphemeris is the predicted position of a Scout object at one
+// time, taken as the median over the sampled orbits.
+type ScoutEphemeris struct {
+	Time     time.Time // Time of the position (UT)
+	RA       *float64  // Right ascension (deg)
+	Dec      *float64  // Declination (deg)
+	DRA      *float64  // Rate of change of right ascension (arcsec/min)
+	DDec     *float64  // Rate of change of declination (arcsec/min)
+	VMag     *float64  // Visual magnitude
+	Elong    *float64  // Solar elongation (deg)
+	El       *float64  // Elevation above the horizon (deg)
+	SigmaPos *float64  // 1-sigma plane-of-sky uncertainty (arcmin)
+}
+
+// ScoutURL builds a URL for the Scout API request represented by q. If
+// Client.ScoutEndpoint is empty, the default ScoutEndpoint is used.
+func (c *Client) ScoutURL(q ScoutQuery) (*url.URL, error) {
+	return queryURL(c.ScoutEndpoint, ScoutEndpoint, q)
+}
+
+// ScoutList returns the Scout summary list of every object it currently
+// tracks.
+func (c *Client) ScoutList(ctx context.Context) ([]ScoutEntry, error) {
+	var out struct {
+		Data []map[string]any `json:"data"`
+	}
+	if err := c.getScout(ctx, ScoutQuery{}, &out); err != nil {
+		return nil, err
+	}
+	entries := make([]ScoutEntry, len(out.Data))
+	for i, m := range out.Data {
+		entries[i] = recordOf(m).scoutEntry()
+	}
+	return entries, nil
+}
+
+// ScoutObject returns the Scout assessment of the object selected by q.
+// It returns an error wrapping ErrNotFound if Scout does not list the
+// object, for example because it has left the NEOCP.
+//
+// Scout drops an object once the MPC designates it and does not report
+// the new designation, so it cannot tell whether the object has reached
+// SBDB. Use Client.Lookup with the designation the MPC announced instead.
+func (c *Client) ScoutObject(ctx context.Context, q ScoutQuery) (*ScoutDetail, error) {
+	if q.Designation == "" {
+		return nil, errors.New("must provide Designation")
+	}
+	// Split the response once: "orbits" and "eph" hold the requested
+	// outputs and every other member is part of the summary.
+	var members map[string]json.RawMessage
+	if err := c.getScout(ctx, q, &members); err != nil {
+		return nil, err
+	}
+	var out struct {
+		Orbits *struct {
+			Fields []string `json:"fields"`
+			Data   [][]any  `json:"data"`
+		}
+		Eph []struct {
+			Time     any            `json:"time"`
+			SigmaPos any            `json:"sigma-pos"`
+			Median   map[string]any `json:"median"`
+		}
+	}
+	summary := make(Record, len(members))
+	for name, raw := range members {
+		var err error
+		switch name {
+		case "orbits":
+			err = decodeNumbers(raw, &out.Orbits)
+		case "eph":
+			err = decodeNumbers(raw, &out.Eph)
+		default:
+			var v any
+			err = decodeNumbers(raw, &v)
+			summary[Field(name)] = v
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	if summary["objectName"] == nil {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, q.Designation)
+	}
+
+	d := &ScoutDetail{ScoutEntry: summary.scoutEntry()}
+	if out.Orbits != nil {
+		p := Payload{Fields: out.Orbits.Fields, Data: out.Orbits.Data}
+		records, err := p.Records()
+		if err != nil {
+			return nil, err
+		}
+		for _, rec := range records {
+			d.Orbits = append(d.Orbits, rec.scoutOrbit())
+		}
+	}
+	for _, e := range out.Eph {
+		r := recordOf(e.Median)
+		r["time"], r["sigma-pos"] = e.Time, e.SigmaPos
+		d.Ephemeris = append(d.Ephemeris, r.scoutEphemeris())
+	}
+	return d, nil
+}
+
+// getScout issues a Scout API request and decodes the response into v.
+func (c *Client) getScout(ctx context.Context, q ScoutQuery, v any) error {
+	u, err := c.ScoutURL(q)
+	if err != nil {
+		return err
+	}
+	if q.Designation != "" {
+		return c.getSSDObject(ctx, u, "objectName", v)
+	}
+	return c.getSSD(ctx, u, v)
+}
+
+func (r Record) scoutEntry() ScoutEntry {
+	return ScoutEntry{
+		LastRun:         deref(r.getTime("lastRun")),
+		Name:            deref(r.getString("objectName")),
+		NObs:            r.getInt("nObs"),
+		Arc:             r.getFloat("arc"),
+		RMSN:            r.getFloat("rmsN"),
+		H:               r.getFloat("H"),
+		VMag:            r.getFloat("Vmag"),
+		RA:              deref(r.getString("ra")),
+		Dec:             deref(r.getString("dec")),
+		Elong:           r.getFloat("elong"),
+		Rate:            r.getFloat("rate"),
+		Unc:             r.getFloat("unc"),
+		UncP1:           r.getFloat("uncP1"),
+		NEOScore:        r.getInt("neoScore"),
+		NEO1kmScore:     r.getInt("neo1kmScore"),
+		PHAScore:        r.getInt("phaScore"),
+		IEOScore:        r.getInt("ieoScore"),
+		GeocentricScore: r.getInt("geocentricScore"),
+		TisserandScore:  r.getInt("tisserandScore"),
+		MOID:            r.getFloat("moid"),
+		VInf:            r.getFloat("vInf"),
+		CADist:          r.getFloat("caDist"),
+		Rating:          r.getInt("rating"),
+		TEphem:          deref(r.getTime("tEphem")),
+	}
+}
+
+func (r Record) scoutOrbit() ScoutOrbit {
+	return ScoutOrbit{
+		Index:          r.getInt("idx"),
+		Epoch:          r.getFloat("epoch"),
+		Eccentricity:   r.getFloat("ec"),
+		PerihelionDist: r.getFloat("qr"),
+		PeriapsisTime:  r.getFloat("tp"),
+		AscNode:        r.getFloat("om"),
+		PeriapsisArg:   r.getFloat("w"),
+		Inclination:    r.getFloat("inc"),
+		H:              r.getFloat("H"),
+		Encounter: ScoutEncounter{
+			Dist:    r.getFloat("dca"),
+			JD:      r.getFloat("tca"),
+			MOID:    r.getFloat("moid"),
+			VInf:    r.getFloat("vinf"),
+			GeoEcc:  r.getFloat("geoEcc"),
+			ImpFlag: r.getInt("impFlag"),
+		},
+	}
+}
+
+func (r Record) scoutEphemeris() ScoutEphemeris {
+	return ScoutEphemeris{
+		Time:     deref(r.getTime("time")),
+		RA:       r.getFloat("ra"),
+		Dec:      r.getFloat("dec"),
+		DRA:      r.getFloat("dra"),
+		DDec:     r.getFloat("ddec"),
+		VMag:     r.getFloat("vmag"),
+		Elong:    r.getFloat("elong"),
+		El:       r.getFloat("el"),
+		SigmaPos: r.getFloat("sigma-pos"),
+	}
+}
@@ -0,0 +1,203 @@
+package sbdb
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestScoutQuery_Values(t *testing.T) {
+	start := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		q       ScoutQuery
+		want    url.Values
+		wantErr bool
+	}{
+		{name: "list", q: ScoutQuery{}, want: url.Values{}},
+		{name: "object", q: ScoutQuery{Designation: "P21Lc6k"}, want: url.Values{"tdes": {"P21Lc6k"}}},
+		{
+			name: "full",
+			q: ScoutQuery{
+				Designation: "P21Lc6k", Orbits: true, EphStart: start, EphStop: start.Add(6 * time.Hour),
+				EphStep: "1h", ObsCode: "G96",
+			},
+			want: url.Values{
+				"tdes": {"P21Lc6k"}, "orbits": {"1"}, "eph-start": {"2024-05-01T00:00:00"},
+				"eph-stop": {"2024-05-01T06:00:00"}, "eph-step": {"1h"}, "obs-code": {"G96"},
+			},
+		},
+		{name: "orbits without designation", q: ScoutQuery{Orbits: true}, wantErr: true},
+		{name: "ephemeris without designation", q: ScoutQuery{EphStart: start}, wantErr: true},
+		{name: "step without start", q: ScoutQuery{Designation: "P21Lc6k", EphStep: "1h"}, wantErr: true},
+		{name: "stop before start", q: ScoutQuery{Designation: "P21Lc6k", EphStart: start, EphStop: start.Add(-time.Hour)}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.q.Values()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Values() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Values() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestClient_ScoutList(t *testing.T) {
+	srv, query := apiServer(t, http.StatusOK, `{"signature":{"source":"NASA/JPL Scout API","version":"1.3"},"count":"1","data":[
+		{"objectName":"P21Lc6k","lastRun":"2024-05-01 12:31","nObs":6,"arc":"0.06","rmsN":"0.37","H":"24.4","Vmag":"21.0",
+		"ra":"03:47","dec":"+18","elong":"146","rate":"3.2","unc":"22","uncP1":"77","neoScore":100,"neo1kmScore":0,
+		"phaScore":1,"ieoScore":0,"geocentricScore":0,"tisserandScore":3,"moid":"0.03","vInf":"5.8","caDist":"0.041",
+		"rating":0,"tEphem":"2024-05-01 12:30"}
+	]}`)
+	c := &Client{ScoutEndpoint: srv.URL}
+	got, err := c.ScoutList(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []ScoutEntry{{
+		Name: "P21Lc6k", LastRun: time.Date(2024, 5, 1, 12, 31, 0, 0, time.UTC), NObs: ptrTo(6), Arc: ptrTo(0.06),
+		RMSN: ptrTo(0.37), H: ptrTo(24.4), VMag: ptrTo(21.0), RA: "03:47", Dec: "+18", Elong: ptrTo(146.0), Rate: ptrTo(3.2),
+		Unc: ptrTo(22.0), UncP1: ptrTo(77.0), NEOScore: ptrTo(100), NEO1kmScore: ptrTo(0), PHAScore: ptrTo(1),
+		IEOScore: ptrTo(0), GeocentricScore: ptrTo(0), TisserandScore: ptrTo(3), MOID: ptrTo(0.03), VInf: ptrTo(5.8),
+		CADist: ptrTo(0.041), Rating: ptrTo(0), TEphem: time.Date(2024, 5, 1, 12, 30, 0, 0, time.UTC),
+	}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ScoutList() mismatch (-want +got):\n%s", diff)
+	}
+	if len(*query) != 0 {
+		t.Errorf("query = %v, want none", *query)
+	}
+}
+
+func TestClient_ScoutObject(t *testing.T) {
+	srv, query := apiServer(t, http.StatusOK, `{"signature":{"version":"1.3"},"objectName":"P21Lc6k","nObs":6,"H":"24.4",
+		"orbits":{"count":"2","fields":["idx","epoch","ec","qr","tp","om","w","inc","H","dca","tca","moid","vinf","geoEcc","impFlag"],
+		"data":[[0,"2460431.5","0.41","0.92","2460500.1","41.2","250.3","3.1","24.3","12.5","2460520.4","0.002","6.1","1.9",0],
+		[1,"2460431.5","0.43","0.90","2460499.8","41.0","251.0","3.3","24.5",null,null,"0.004","6.4","2.1",0]]},
+		"eph":[{"time":"2024-05-01 00:00","sigma-pos":"4.2","median":{"ra":"56.75","dec":"18.2","dra":"1.1","ddec":"-0.4",
+		"vmag":"21.0","elong":"146","el":"35"}}]}`)
+	c := &Client{ScoutEndpoint: srv.URL}
+	got, err := c.ScoutObject(context.Background(), ScoutQuery{
+		Designation: "P21Lc6k", Orbits: true, EphStart: time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &ScoutDetail{
+		ScoutEntry: ScoutEntry{Name: "P21Lc6k", NObs: ptrTo(6), H: ptrTo(24.4)},
+		Orbits: []ScoutOrbit{
+			{
+				Index: ptrTo(0), Epoch: ptrTo(2460431.5), Eccentricity: ptrTo(0.41), PerihelionDist: ptrTo(0.92),
+				PeriapsisTime: ptrTo(2460500.1), AscNode: ptrTo(41.2), PeriapsisArg: ptrTo(250.3), Inclination: ptrTo(3.1),
+				H: ptrTo(24.3),
+				Encounter: ScoutEncounter{
+					Dist: ptrTo(12.5), JD: ptrTo(2460520.4), MOID: ptrTo(0.002), VInf: ptrTo(6.1), GeoEcc: ptrTo(1.9), ImpFlag: ptrTo(0),
+				},
+			},
+			{
+				Index: ptrTo(1), Epoch: ptrTo(2460431.5), Eccentricity: ptrTo(0.43), PerihelionDist: ptrTo(0.90),
+				PeriapsisTime: ptrTo(2460499.8), AscNode: ptrTo(41.0), PeriapsisArg: ptrTo(251.0), Inclination: ptrTo(3.3),
+				H:         ptrTo(24.5),
+				Encounter: ScoutEncounter{MOID: ptrTo(0.004), VInf: ptrTo(6.4), GeoEcc: ptrTo(2.1), ImpFlag: ptrTo(0)},
+			},
+		},
+		Ephemeris: []ScoutEphemeris{{
+			Time: time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC), RA: ptrTo(56.75), Dec: ptrTo(18.2), DRA: ptrTo(1.1),
+			DDec: ptrTo(-0.4), VMag: ptrTo(21.0), Elong: ptrTo(146.0), El: ptrTo(35.0), SigmaPos: ptrTo(4.2),
+		}},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ScoutObject() mismatch (-want +got):\n%s", diff)
+	}
+	if q := query.Get("orbits"); q != "1" {
+		t.Errorf("orbits = %q, want 1", q)
+	}
+}
+
+func TestClient_ScoutObject_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		notFound bool
+		apiErr   bool
+	}{
+		{"not found", `{"error":"specified object not found"}`, true, false},
+		{"no summary", `{"signature":{"version":"1.3"}}`, true, false},
+		{"other", `{"error":"invalid eph-step"}`, false, true},
+		{"invalid json", `{`, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests int
+			srv, _ := apiServerFunc(t, func(url.Values) (int, string) {
+				requests++
+				return http.StatusOK, tt.body
+			})
+			c := &Client{ScoutEndpoint: srv.URL, Cache: NewMemoryCache(10)}
+			q := ScoutQuery{Designation: "P21Lc6k"}
+			_, err := c.ScoutObject(context.Background(), q)
+			if err == nil {
+				t.Fatal("ScoutObject() error = nil, want error")
+			}
+			if _, err := c.ScoutObject(context.Background(), q); err == nil {
+				t.Error("second ScoutObject() error = nil, want error")
+			}
+			if requests != 2 {
+				t.Errorf("requests = %d, want 2 (errors are not cached)", requests)
+			}
+			if errors.Is(err, ErrNotFound) != tt.notFound {
+				t.Errorf("ScoutObject() error = %v, want ErrNotFound = %v", err, tt.notFound)
+			}
+			var apiErr *APIError
+			if errors.As(err, &apiErr) != tt.apiErr {
+				t.Errorf("ScoutObject() error = %v, want *APIError = %v", err, tt.apiErr)
+			}
+			if tt.apiErr && (apiErr.StatusCode != http.StatusOK || apiErr.Message == "") {
+				t.Errorf("APIError = %+v, want status %d with a message", apiErr, http.StatusOK)
+			}
+		})
+	}
+}
+
+func TestClient_ScoutObject_Designated(t *testing.T) {
+	// The object listed by Scout is designated 433 by the MPC and leaves
+	// the NEOCP: Scout no longer reports it and SBDB lists it under its
+	// new designation.
+	srv, query := apiServerFunc(t, func(q url.Values) (int, string) {
+		switch {
+		case q.Has("tdes"):
+			return http.StatusOK, `{"signature":{"version":"1.3"},"error":"specified object not found"}`
+		case q.Has("des"):
+			return http.StatusOK, erosObject
+		}
+		return http.StatusOK, `{"signature":{"version":"1.3"},"count":"1","data":[{"objectName":"P21Lc6k","nObs":6}]}`
+	})
+	c := &Client{ScoutEndpoint: srv.URL, LookupEndpoint: srv.URL}
+	ctx := context.Background()
+
+	entries, err := c.ScoutList(ctx)
+	if err != nil || len(entries) != 1 {
+		t.Fatalf("ScoutList() = %v, %v", entries, err)
+	}
+	if _, err := c.ScoutObject(ctx, ScoutQuery{Designation: entries[0].Name}); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("ScoutObject() error = %v, want ErrNotFound", err)
+	}
+	if q := query.Get("tdes"); q != "P21Lc6k" {
+		t.Errorf("tdes = %q, want P21Lc6k", q)
+	}
+	obj, err := c.Lookup(ctx, ObjectQuery{Designation: "433"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if spkid := obj.Body.Identity.SpkID; spkid == nil || *spkid != 2000433 {
+		t.Errorf("Lookup() SpkID = %v, want 2000433", spkid)
+	}
+}
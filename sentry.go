@@ -0,0 +1,333 @@
+package sbdb
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SentryEndpoint is the default base URL for the Sentry impact-risk API.
+// It can be overridden via Client.SentryEndpoint.
+const SentryEndpoint = "https://ssd-api.jpl.nasa.gov/sentry.api"
+
+// SentryQuery defines the parameters for the Sentry API, documented at
+// https://ssd-api.jpl.nasa.gov/doc/sentry.html. It either filters the
+// summary list or, with Designation, selects a single object. Zero values
+// are not sent.
+type SentryQuery struct {
+	// Designation selects a single object, as used by SentryObject. It
+	// cannot be combined with the summary list filters.
+	Designation string
+
+	HMax  float64 // Maximum absolute magnitude
+	PSMin float64 // Minimum Palermo scale; usually negative
+	IPMin float64 // Minimum cumulative impact probability
+	Days  int     // Only objects observed within this many days
+}
+
+// Values converts q into URL query parameters.
+func (q SentryQuery) Values() (url.Values, error) {
+	if q.Designation != "" && (q.HMax != 0 || q.PSMin != 0 || q.IPMin != 0 || q.Days != 0) {
+		return nil, errors.New("must not provide both Designation and list filters")
+	}
+
+	v := url.Values{}
+	p := params(v)
+	p.setString("des", q.Designation)
+	p.setFloat("h-max", q.HMax)
+	p.setFloat("ps-min", q.PSMin)
+	p.setFloat("ip-min", q.IPMin)
+	if q.Days != 0 {
+		v.Set("days", strconv.Itoa(q.Days))
+	}
+	return v, nil
+}
+
+// SentryEntry is an object in the Sentry summary list: a body with at
+// least one potential future Earth impact.
+type SentryEntry struct {
+	Designation string    // Primary designation
+	FullName    string    // Full name
+	ID          string    // Sentry object identifier
+	IP          *float64  // Cumulative impact probability
+	NImp        *int      // Number of potential impacts
+	PSCum       *float64  // Cumulative Palermo scale
+	PSMax       *float64  // Maximum Palermo scale
+	TSMax       *int      // Maximum Torino scale
+	H           *float64  // Absolute magnitude
+	Diameter    *float64  // Estimated diameter (km)
+	VInf        *float64  // Velocity relative to a massless Earth (km/s)
+	Range       string    // Years of potential impacts, e.g. "2069-2122"
+	LastObs     time.Time // Date of the last observation
+	LastObsJD   *float64  // Date of the last observation (JD)
+}
+
+// SentryDetail is the Sentry risk assessment of a single object.
+type SentryDetail struct {
+	SentryEntry
+	Method   string    // Impact monitoring method, e.g. "IOBS" or "LOV"
+	Mass     *float64  // Estimated mass (kg)
+	Energy   *float64  // Estimated impact energy (Mt)
+	VImp     *float64  // Impact velocity (km/s)
+	FirstObs time.Time // Date of the first observation
+	DataArc  string    // Observation arc
+	NObs     *int      // Number of optical observations
+	NDel     *int      // Number of radar delay observations
+	NDop     *int      // Number of radar Doppler observations
+	NSat     *int      // Number of satellite observations
+	PDate    time.Time // Date of the analysis (UT)
+	CDate    time.Time // Date of the orbit solution (UT)
+
+	// VirtualImpactors lists the potential impacts, one per virtual
+	// impactor.
+	VirtualImpactors []VirtualImpactor
+}
+
+// VirtualImpactor is one potential impact of a Sentry object.
+type VirtualImpactor struct {
+	Time     time.Time // Time of potential impact (TDB)
+	IP       *float64  // Impact probability
+	PS       *float64  // Palermo scale
+	TS       *int      // Torino scale
+	Energy   *float64  // Impact energy (Mt)
+	Dist     *float64  // Minimum distance from the line of variations (Earth radii)
+	Width    *float64  // 1-sigma semi-width of the uncertainty region (Earth radii)
+	SigmaVI  *float64  // Distance of the virtual impactor from the nominal orbit (sigma)
+	SigmaImp *float64  // Lateral distance from Earth's center (sigma)
+	Stretch  *float64  // Stretching of the uncertainty region (Earth radii per sigma)
+}
+
+// SentryURL builds a URL for the Sentry API request represented by q. If
+// Client.SentryEndpoint is empty, the default SentryEndpoint is used.
+func (c *Client) SentryURL(q SentryQuery) (*url.URL, error) {
+	return queryURL(c.SentryEndpoint, SentryEndpoint, q)
+}
+
+// SentryList returns the Sentry summary list of objects matching q.
+// q.Designation must be empty; use SentryObject for a single object.
+func (c *Client) SentryList(ctx context.Context, q SentryQuery) ([]SentryEntry, error) {
+	if q.Designation != "" {
+		return nil, errors.New("must not provide Designation; use SentryObject")
+	}
+	var out struct {
+		Data []map[string]any `json:"data"`
+	}
+	if err := c.getSentry(ctx, q, &out); err != nil {
+		return nil, err
+	}
+	entries := make([]SentryEntry, len(out.Data))
+	for i, m := range out.Data {
+		entries[i] = recordOf(m).sentryEntry()
+	}
+	return entries, nil
+}
+
+// SentryObject returns the Sentry risk assessment, including virtual
+// impactors, of the object with the given designation. It returns an
+// error wrapping ErrNotFound if Sentry does not list the object, for
+// example because it has been removed.
+func (c *Client) SentryObject(ctx context.Context, des string) (*SentryDetail, error) {
+	if des == "" {
+		return nil, errors.New("must provide designation")
+	}
+	var out struct {
+		Summary map[string]any   `json:"summary"`
+		Data    []map[string]any `json:"data"`
+	}
+	if err := c.getSentry(ctx, SentryQuery{Designation: des}, &out); err != nil {
+		return nil, err
+	}
+	if out.Summary == nil {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, des)
+	}
+	r := recordOf(out.Summary)
+	d := &SentryDetail{
+		SentryEntry: r.sentryEntry(),
+		Method:      deref(r.getString("method")),
+		Mass:        r.getFloat("mass"),
+		Energy:      r.getFloat("energy"),
+		VImp:        r.getFloat("v_imp"),
+		FirstObs:    deref(r.getTime("first_obs")),
+		DataArc:     deref(r.getString("darc")),
+		NObs:        r.getInt("nobs"),
+		NDel:        r.getInt("ndel"),
+		NDop:        r.getInt("ndop"),
+		NSat:        r.getInt("nsat"),
+		PDate:       deref(r.getTime("pdate")),
+		CDate:       deref(r.getTime("cdate")),
+	}
+	for _, m := range out.Data {
+		d.VirtualImpactors = append(d.VirtualImpactors, recordOf(m).virtualImpactor())
+	}
+	return d, nil
+}
+
+// getSentry issues a Sentry API request and decodes the response into v.
+func (c *Client) getSentry(ctx context.Context, q SentryQuery, v any) error {
+	u, err := c.SentryURL(q)
+	if err != nil {
+		return err
+	}
+	if q.Designation != "" {
+		return c.getSSDObject(ctx, u, "summary", v)
+	}
+	return c.getSSD(ctx, u, v)
+}
+
+// Messages the Sentry and Scout APIs report for objects they do not list.
+const (
+	ssdNotFound = "specified object not found"
+	ssdRemoved  = "specified object removed"
+)
+
+// getSSD issues a request to one of the SSD APIs that report problems in
+// an "error" member rather than with an HTTP status, and decodes the
+// response into v. Responses for unknown or removed objects wrap
+// ErrNotFound; any other error member is returned as an *APIError.
+// Responses with an error member are never cached.
+func (c *Client) getSSD(ctx context.Context, u *url.URL, v any) error {
+	return c.getSSDObject(ctx, u, "", v)
+}
+
+// getSSDObject is getSSD for a request that selects one object, which the
+// response describes in member. A response without member is decoded but
+// not cached, so the caller can report the object as not found without
+// the answer outliving the request.
+func (c *Client) getSSDObject(ctx context.Context, u *url.URL, member string, v any) error {
+	resp, err := c.getChecked(ctx, u, ssdCacheable(member))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	var status struct {
+		Error   *string `json:"error"`
+		Removed string  `json:"removed"`
+	}
+	if err := json.Unmarshal(body, &status); err != nil {
+		return fmt.Errorf("decode failed: %w", err)
+	}
+	switch {
+	case status.Error == nil:
+		return decodeNumbers(body, v)
+	case *status.Error == ssdRemoved:
+		return fmt.Errorf("%w: %s (removed %s)", ErrNotFound, *status.Error, status.Removed)
+	case *status.Error == ssdNotFound:
+		return fmt.Errorf("%w: %s", ErrNotFound, *status.Error)
+	}
+	return &APIError{StatusCode: resp.StatusCode, Message: *status.Error, URL: u.String(), Body: body}
+}
+
+// ssdCacheable returns a check for getChecked that rejects SSD responses
+// with an "error" member and, if member is not empty, responses without a
+// non-null member.
+func ssdCacheable(member string) func([]byte) bool {
+	return func(body []byte) bool {
+		var m map[string]json.RawMessage
+		if err := json.Unmarshal(body, &m); err != nil {
+			return false
+		}
+		if _, ok := m["error"]; ok {
+			return false
+		}
+		if member == "" {
+			return true
+		}
+		v, ok := m[member]
+		return ok && string(v) != "null"
+	}
+}
+
+// recordOf converts a JSON object to a Record so its values can be read
+// with the same conversion rules as SBDB payloads.
+func recordOf(m map[string]any) Record {
+	r := make(Record, len(m))
+	for k, v := range m {
+		r[Field(k)] = v
+	}
+	return r
+}
+
+func (r Record) sentryEntry() SentryEntry {
+	return SentryEntry{
+		Designation: deref(r.getString("des")),
+		FullName:    strings.TrimSpace(deref(r.getString("fullname"))),
+		ID:          deref(r.getString("id")),
+		IP:          r.getFloat("ip"),
+		NImp:        r.getInt("n_imp"),
+		PSCum:       r.getFloat("ps_cum"),
+		PSMax:       r.getFloat("ps_max"),
+		TSMax:       r.getInt("ts_max"),
+		H:           r.getFloat("h"),
+		Diameter:    r.getFloat("diameter"),
+		VInf:        r.getFloat("v_inf"),
+		Range:       deref(r.getString("range")),
+		LastObs:     deref(r.getTime("last_obs")),
+		LastObsJD:   r.getFloat("last_obs_jd"),
+	}
+}
+
+func (r Record) virtualImpactor() VirtualImpactor {
+	return VirtualImpactor{
+		Time:     deref(r.getTime("date")),
+		IP:       r.getFloat("ip"),
+		PS:       r.getFloat("ps"),
+		TS:       r.getInt("ts"),
+		Energy:   r.getFloat("energy"),
+		Dist:     r.getFloat("dist"),
+		Width:    r.getFloat("width"),
+		SigmaVI:  r.getFloat("sigma_vi"),
+		SigmaImp: r.getFloat("sigma_imp"),
+		Stretch:  r.getFloat("stretch"),
+	}
+}
+
+// SentryBody pairs a Body with its Sentry entry, if it has one.
+type SentryBody struct {
+	Body   Body
+	Sentry *SentryEntry
+}
+
+// JoinSentry pairs each body with the Sentry entry of the same object,
+// matching Identity.PDES against the entry designation and falling back
+// to the full name. Bodies without a Sentry entry have a nil Sentry.
+func JoinSentry(bodies []Body, entries []SentryEntry) []SentryBody {
+	byDes := make(map[string]*SentryEntry, len(entries))
+	byName := make(map[string]*SentryEntry, len(entries))
+	for i := range entries {
+		e := &entries[i]
+		if e.Designation != "" {
+			byDes[normalizeDesignation(e.Designation)] = e
+		}
+		if e.FullName != "" {
+			byName[normalizeDesignation(e.FullName)] = e
+		}
+	}
+	out := make([]SentryBody, len(bodies))
+	for i, b := range bodies {
+		out[i].Body = b
+		if b.Identity.PDES != nil {
+			out[i].Sentry = byDes[normalizeDesignation(*b.Identity.PDES)]
+		}
+		if out[i].Sentry == nil && b.Identity.FullName != nil {
+			out[i].Sentry = byName[normalizeDesignation(*b.Identity.FullName)]
+		}
+	}
+	return out
+}
+
+// normalizeDesignation collapses runs of spaces so designations and names
+// compare equal regardless of padding.
+func normalizeDesignation(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
@@ -0,0 +1,178 @@
+package sbdb
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSentryQuery_Values(t *testing.T) {
+	tests := []struct {
+		name    string
+		q       SentryQuery
+		want    url.Values
+		wantErr bool
+	}{
+		{name: "defaults", q: SentryQuery{}, want: url.Values{}},
+		{
+			name: "list filters",
+			q:    SentryQuery{HMax: 24, PSMin: -3, IPMin: 1e-6, Days: 30},
+			want: url.Values{"h-max": {"24"}, "ps-min": {"-3"}, "ip-min": {"1e-06"}, "days": {"30"}},
+		},
+		{name: "designation", q: SentryQuery{Designation: "2000 SG344"}, want: url.Values{"des": {"2000 SG344"}}},
+		{name: "designation with filters", q: SentryQuery{Designation: "2000 SG344", PSMin: -3}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.q.Values()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Values() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Values() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestClient_SentryList(t *testing.T) {
+	srv, query := apiServer(t, http.StatusOK, `{"signature":{"source":"NASA/JPL Sentry Data API","version":"2.0"},"count":"1","data":[
+		{"des":"29075","fullname":"29075 (1950 DA)","id":"a0029075","ip":"3.8e-04","n_imp":1,"ps_cum":"-0.93","ps_max":"-0.93",
+		"ts_max":null,"h":"17.9","diameter":"1.3","v_inf":"14.1","range":"2880-2880","last_obs":"2021-Feb-06","last_obs_jd":"2459251.5"}
+	]}`)
+	c := &Client{SentryEndpoint: srv.URL}
+	got, err := c.SentryList(context.Background(), SentryQuery{PSMin: -2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []SentryEntry{{
+		Designation: "29075", FullName: "29075 (1950 DA)", ID: "a0029075", IP: ptrTo(3.8e-4), NImp: ptrTo(1),
+		PSCum: ptrTo(-0.93), PSMax: ptrTo(-0.93), H: ptrTo(17.9), Diameter: ptrTo(1.3), VInf: ptrTo(14.1),
+		Range: "2880-2880", LastObs: time.Date(2021, 2, 6, 0, 0, 0, 0, time.UTC), LastObsJD: ptrTo(2459251.5),
+	}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("SentryList() mismatch (-want +got):\n%s", diff)
+	}
+	if q := query.Get("ps-min"); q != "-2" {
+		t.Errorf("ps-min = %q, want -2", q)
+	}
+}
+
+func TestClient_SentryObject(t *testing.T) {
+	srv, query := apiServer(t, http.StatusOK, `{"signature":{"version":"2.0"},
+		"summary":{"des":"2000 SG344","fullname":"(2000 SG344)","method":"IOBS","ip":"2.7e-03","n_imp":300,"ps_cum":"-2.77",
+		"ps_max":"-3.08","ts_max":"0","h":"24.79","diameter":"0.037","mass":"7.1e+07","energy":"1.4e+00","v_inf":"1.36",
+		"v_imp":"11.31","first_obs":"2000-09-29","last_obs":"2000-10-07","darc":"8 days","nobs":"27","ndel":"0","ndop":"0",
+		"nsat":"0","pdate":"2021-04-01","cdate":"2021-04-01 08:00:00"},
+		"data":[{"date":"2069-09-21.25","energy":"1.4e+00","ip":"8.3e-04","ps":"-3.08","ts":"0","dist":"0.77","width":"5.4",
+		"sigma_vi":"-0.29","sigma_imp":"0.0","stretch":"2.1e+04"}]}`)
+	c := &Client{SentryEndpoint: srv.URL}
+	got, err := c.SentryObject(context.Background(), "2000 SG344")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &SentryDetail{
+		SentryEntry: SentryEntry{
+			Designation: "2000 SG344", FullName: "(2000 SG344)", IP: ptrTo(2.7e-3), NImp: ptrTo(300), PSCum: ptrTo(-2.77),
+			PSMax: ptrTo(-3.08), TSMax: ptrTo(0), H: ptrTo(24.79), Diameter: ptrTo(0.037), VInf: ptrTo(1.36), LastObs: time.Date(2000, 10, 7, 0, 0, 0, 0, time.UTC),
+		},
+		Method: "IOBS", Mass: ptrTo(7.1e7), Energy: ptrTo(1.4), VImp: ptrTo(11.31), FirstObs: time.Date(2000, 9, 29, 0, 0, 0, 0, time.UTC), DataArc: "8 days",
+		NObs: ptrTo(27), NDel: ptrTo(0), NDop: ptrTo(0), NSat: ptrTo(0), PDate: time.Date(2021, 4, 1, 0, 0, 0, 0, time.UTC), CDate: time.Date(2021, 4, 1, 8, 0, 0, 0, time.UTC),
+		VirtualImpactors: []VirtualImpactor{{
+			Time: time.Date(2069, 9, 21, 6, 0, 0, 0, time.UTC), IP: ptrTo(8.3e-4), PS: ptrTo(-3.08), TS: ptrTo(0),
+			Energy: ptrTo(1.4), Dist: ptrTo(0.77), Width: ptrTo(5.4), SigmaVI: ptrTo(-0.29), SigmaImp: ptrTo(0.0), Stretch: ptrTo(2.1e4),
+		}},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("SentryObject() mismatch (-want +got):\n%s", diff)
+	}
+	if q := query.Get("des"); q != "2000 SG344" {
+		t.Errorf("des = %q, want 2000 SG344", q)
+	}
+}
+
+func TestClient_SentryObject_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		notFound bool
+		apiErr   bool
+	}{
+		{"removed", `{"error":"specified object removed","removed":"2021-02-22 14:43:10"}`, true, false},
+		{"not found", `{"error":"specified object not found"}`, true, false},
+		{"other", `{"error":"invalid parameter"}`, false, true},
+		{"mentions not found", `{"error":"ephemeris file not found"}`, false, true},
+		{"no summary", `{"signature":{}}`, true, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests int
+			srv, _ := apiServerFunc(t, func(url.Values) (int, string) {
+				requests++
+				return http.StatusOK, tt.body
+			})
+			c := &Client{SentryEndpoint: srv.URL, Cache: NewMemoryCache(10)}
+			_, err := c.SentryObject(context.Background(), "x")
+			if err == nil {
+				t.Fatal("SentryObject() error = nil, want error")
+			}
+			if _, err := c.SentryObject(context.Background(), "x"); err == nil {
+				t.Error("second SentryObject() error = nil, want error")
+			}
+			if requests != 2 {
+				t.Errorf("requests = %d, want 2 (errors are not cached)", requests)
+			}
+			if errors.Is(err, ErrNotFound) != tt.notFound {
+				t.Errorf("SentryObject() error = %v, want ErrNotFound = %v", err, tt.notFound)
+			}
+			var apiErr *APIError
+			if errors.As(err, &apiErr) != tt.apiErr {
+				t.Errorf("SentryObject() error = %v, want *APIError = %v", err, tt.apiErr)
+			}
+			if tt.apiErr && (apiErr.StatusCode != http.StatusOK || apiErr.Message == "") {
+				t.Errorf("APIError = %+v, want status %d with a message", apiErr, http.StatusOK)
+			}
+		})
+	}
+}
+
+func TestJoinSentry(t *testing.T) {
+	entries := []SentryEntry{
+		{Designation: "29075", FullName: "29075 (1950 DA)"},
+		{Designation: "2000 SG344", FullName: "(2000 SG344)"},
+	}
+	bodies := []Body{
+		{Identity: Identity{PDES: ptrTo("29075")}},
+		{Identity: Identity{FullName: ptrTo("(2000  SG344)")}},
+		{Identity: Identity{PDES: ptrTo("433"), FullName: ptrTo("433 Eros (A898 PA)")}},
+	}
+	got := JoinSentry(bodies, entries)
+	if len(got) != 3 {
+		t.Fatalf("JoinSentry() returned %d bodies", len(got))
+	}
+	if got[0].Sentry != &entries[0] || got[1].Sentry != &entries[1] || got[2].Sentry != nil {
+		t.Errorf("JoinSentry() = %+v", got)
+	}
+}
+
+func TestClient_SentryObject_Cache(t *testing.T) {
+	var requests int
+	srv, _ := apiServerFunc(t, func(url.Values) (int, string) {
+		requests++
+		return http.StatusOK, `{"signature":{"version":"2.0"},"summary":{"des":"29075"},"data":[]}`
+	})
+	c := &Client{SentryEndpoint: srv.URL, Cache: NewMemoryCache(10)}
+	for i := 0; i < 2; i++ {
+		if _, err := c.SentryObject(context.Background(), "29075"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if requests != 1 {
+		t.Errorf("requests = %d, want 1", requests)
+	}
+}
@@ -0,0 +1,222 @@
+package sbdb
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Stream decodes an SBDB JSON payload incrementally, yielding one row at a
+// time instead of buffering the whole data array like Decode. Only the
+// current row is held in memory, so arbitrarily large responses can be
+// processed.
+//
+// Rows are streamed when "fields" precedes "data", as it does in
+// responses from the SBDB API. Like Decode, Stream also accepts "data"
+// first, but then it must buffer the whole data array until the field
+// names are read. Signature, Fields and Count are populated as they are
+// read; in API responses all three precede the data, so they are
+// available after the first call to Next.
+//
+// A Stream is not safe for concurrent use.
+type Stream struct {
+	dec     *json.Decoder
+	sig     Signature
+	fields  []string
+	count   int
+	started bool
+	inData  bool
+	done    bool
+	// buffered holds the rows of a data array read before the fields.
+	buffered [][]any
+	row      int
+	rec      Record
+	err      error
+}
+
+// NewStream returns a Stream reading from r. Like Decode, numeric values
+// are decoded as json.Number.
+func NewStream(r io.Reader) *Stream {
+	s := &Stream{}
+	if r == nil {
+		s.err = errors.New("nil reader")
+		return s
+	}
+	if _, ok := r.(*bufio.Reader); !ok {
+		r = bufio.NewReader(r)
+	}
+	s.dec = json.NewDecoder(r)
+	s.dec.UseNumber()
+	return s
+}
+
+// Next advances to the next data row. It returns false at the end of the
+// payload or on error; check Err to tell the two apart.
+func (s *Stream) Next() bool {
+	if s.err != nil {
+		return false
+	}
+	if s.done {
+		return s.nextBuffered()
+	}
+	if !s.inData {
+		if !s.readUntilData() {
+			return s.nextBuffered()
+		}
+	}
+	if s.dec.More() {
+		var row []any
+		if err := s.dec.Decode(&row); err != nil {
+			s.fail(fmt.Errorf("data element %d: %w", s.row, err))
+			return false
+		}
+		return s.setRow(row)
+	}
+
+	// Consume the closing bracket of the data array and any members
+	// that follow it.
+	if _, err := s.dec.Token(); err != nil {
+		s.fail(err)
+		return false
+	}
+	s.inData = false
+	s.readUntilData()
+	s.done = true
+	return false
+}
+
+// nextBuffered advances to the next row of a data array that preceded
+// the fields, once the whole payload has been read.
+func (s *Stream) nextBuffered() bool {
+	if s.err != nil || len(s.buffered) == 0 {
+		return false
+	}
+	row := s.buffered[0]
+	s.buffered = s.buffered[1:]
+	return s.setRow(row)
+}
+
+// setRow makes row the current record.
+func (s *Stream) setRow(row []any) bool {
+	rec, err := newRecord(s.fields, row, s.row)
+	if err != nil {
+		s.fail(err)
+		return false
+	}
+	s.rec = rec
+	s.row++
+	return true
+}
+
+// Record returns the current row as a Record.
+func (s *Stream) Record() Record {
+	return s.rec
+}
+
+// Body returns the current row converted to a Body.
+func (s *Stream) Body() Body {
+	return s.rec.body()
+}
+
+// Err returns the first error encountered while decoding.
+func (s *Stream) Err() error {
+	return s.err
+}
+
+// Signature returns the payload signature, once it has been read.
+func (s *Stream) Signature() Signature {
+	return s.sig
+}
+
+// Fields returns the payload field names, once they have been read.
+func (s *Stream) Fields() []string {
+	return s.fields
+}
+
+// Count returns the payload record count, once it has been read.
+func (s *Stream) Count() int {
+	return s.count
+}
+
+func (s *Stream) fail(err error) {
+	s.err = fmt.Errorf("decode failed: %w", err)
+}
+
+// readUntilData reads object members until the start of a non-empty data
+// array, returning true if one was found. It returns false at the end of
+// the object or on error.
+func (s *Stream) readUntilData() bool {
+	if !s.started {
+		s.started = true
+		if err := s.expectDelim('{'); err != nil {
+			s.fail(err)
+			return false
+		}
+	}
+	for s.dec.More() {
+		tok, err := s.dec.Token()
+		if err != nil {
+			s.fail(err)
+			return false
+		}
+		key, ok := tok.(string)
+		if !ok {
+			s.fail(fmt.Errorf("unexpected token %v", tok))
+			return false
+		}
+		switch key {
+		case "signature":
+			err = s.dec.Decode(&s.sig)
+		case "fields":
+			err = s.dec.Decode(&s.fields)
+		case "count":
+			err = s.dec.Decode(&s.count)
+		case "data":
+			if s.fields == nil {
+				// The rows cannot be converted without the field
+				// names, so hold them until the end of the payload.
+				err = s.dec.Decode(&s.buffered)
+				break
+			}
+			tok, err := s.dec.Token()
+			if err != nil {
+				s.fail(err)
+				return false
+			}
+			if tok == nil {
+				continue // "data": null
+			}
+			if d, ok := tok.(json.Delim); !ok || d != '[' {
+				s.fail(fmt.Errorf("unexpected token %v, expected [", tok))
+				return false
+			}
+			s.inData = true
+			return true
+		default:
+			err = s.dec.Decode(&json.RawMessage{})
+		}
+		if err != nil {
+			s.fail(fmt.Errorf("%s: %w", key, err))
+			return false
+		}
+	}
+	if err := s.expectDelim('}'); err != nil {
+		s.fail(err)
+		return false
+	}
+	s.done = true
+	return false
+}
+
+func (s *Stream) expectDelim(want json.Delim) error {
+	tok, err := s.dec.Token()
+	if err != nil {
+		return err
+	}
+	if d, ok := tok.(json.Delim); !ok || d != want {
+		return fmt.Errorf("unexpected token %v, expected %v", tok, want)
+	}
+	return nil
+}
@@ -0,0 +1,144 @@
+package sbdb
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestStream(t *testing.T) {
+	tests := []struct {
+		name      string
+		data      string
+		want      []Record
+		wantSig   Signature
+		wantCount int
+		wantErr   bool
+	}{
+		{
+			name: "api order",
+			data: `{"signature":{"version":"1.0","source":"NASA/JPL Small-Body Database (SBDB) Query API"},"count":2,` +
+				`"fields":["spkid","neo"],"data":[["20000001","N"],["20000433","Y"]]}`,
+			want: []Record{
+				{SpkID: "20000001", NEO: "N"},
+				{SpkID: "20000433", NEO: "Y"},
+			},
+			wantSig:   Signature{Version: "1.0", Source: "NASA/JPL Small-Body Database (SBDB) Query API"},
+			wantCount: 2,
+		},
+		{
+			name:      "count after data",
+			data:      `{"fields":["spkid"],"data":[[1]],"extra":{"ignored":[1,2]},"count":1}`,
+			want:      []Record{{SpkID: json.Number("1")}},
+			wantCount: 1,
+		},
+		{
+			name: "empty data",
+			data: `{"fields":["spkid"],"data":[],"count":0}`,
+		},
+		{
+			name: "null data",
+			data: `{"fields":["spkid"],"data":null,"count":0}`,
+		},
+		{
+			name: "no data",
+			data: `{"fields":["spkid"],"count":0}`,
+		},
+		{
+			name:      "data before fields",
+			data:      `{"data":[["1","Y"],["2","N"]],"count":2,"fields":["spkid","neo"]}`,
+			want:      []Record{{SpkID: "1", NEO: "Y"}, {SpkID: "2", NEO: "N"}},
+			wantCount: 2,
+		},
+		{
+			name:    "data before mismatched fields",
+			data:    `{"data":[["1","Y"]],"fields":["spkid"]}`,
+			wantErr: true,
+		},
+		{
+			name:    "data without fields",
+			data:    `{"data":[["1"]]}`,
+			wantErr: true,
+		},
+		{
+			name:    "mismatched row",
+			data:    `{"fields":["spkid","neo"],"data":[["1","Y"],["2"]]}`,
+			want:    []Record{{SpkID: "1", NEO: "Y"}},
+			wantErr: true,
+		},
+		{
+			name:    "truncated",
+			data:    `{"fields":["spkid"],"data":[["1"],["2"`,
+			want:    []Record{{SpkID: "1"}},
+			wantErr: true,
+		},
+		{
+			name:    "not an object",
+			data:    `[]`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewStream(strings.NewReader(tt.data))
+			var got []Record
+			for s.Next() {
+				got = append(got, s.Record())
+			}
+			if (s.Err() != nil) != tt.wantErr {
+				t.Fatalf("Err() = %v, wantErr %v", s.Err(), tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("records mismatch (-want +got):\n%s", diff)
+			}
+			if tt.wantErr {
+				return
+			}
+			if diff := cmp.Diff(tt.wantSig, s.Signature()); diff != "" {
+				t.Errorf("signature mismatch (-want +got):\n%s", diff)
+			}
+			if s.Count() != tt.wantCount {
+				t.Errorf("Count() = %d, want %d", s.Count(), tt.wantCount)
+			}
+		})
+	}
+
+	t.Run("nil reader", func(t *testing.T) {
+		s := NewStream(nil)
+		if s.Next() || s.Err() == nil {
+			t.Fatal("expected error for nil reader")
+		}
+	})
+}
+
+func TestStream_Body(t *testing.T) {
+	data := `{"fields":["spkid","full_name","neo","e"],"data":[["1"," 1 Ceres ","N","0.0785"]]}`
+	s := NewStream(strings.NewReader(data))
+	if !s.Next() {
+		t.Fatalf("Next() = false, Err() = %v", s.Err())
+	}
+	want := Body{
+		Identity: Identity{SpkID: ptrTo(1), FullName: ptrTo("1 Ceres"), NEO: ptrTo(false)},
+		Orbit:    Orbit{Eccentricity: ptrTo(0.0785)},
+	}
+	if diff := cmp.Diff(want, s.Body()); diff != "" {
+		t.Errorf("Body() mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"spkid", "full_name", "neo", "e"}, s.Fields()); diff != "" {
+		t.Errorf("Fields() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func FuzzStream(f *testing.F) {
+	f.Add([]byte(`{"fields":["spkid","full_name","neo","t_jup"],"data":[[1234,"name","Y","3.14"]]}`))
+	f.Add([]byte(`{"fields":["spkid"],"data":[[1234]],"count":1}`))
+	f.Add([]byte(`{"data":[],"fields":[]}`))
+	f.Fuzz(func(t *testing.T, data []byte) {
+		s := NewStream(strings.NewReader(string(data)))
+		for s.Next() {
+			_ = s.Body()
+		}
+	})
+}
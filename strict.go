@@ -0,0 +1,87 @@
+package sbdb
+
+import "fmt"
+
+// ConversionError reports a value that could not be converted to the type
+// its field decodes to. Payload.Bodies silently stores nil for such values;
+// Payload.StrictBodies reports them as ConversionErrors.
+type ConversionError struct {
+	Row   int       // Index of the data row
+	Field Field     // Field whose value failed to convert
+	Value any       // Raw value from the payload
+	Type  ValueType // Type the value should have converted to
+}
+
+// Error implements the error interface.
+func (e *ConversionError) Error() string {
+	return fmt.Sprintf("data element %d: cannot convert %s value %v (%T) to %s", e.Row, e.Field, e.Value, e.Value, e.Type)
+}
+
+// ConversionErrors lists every conversion failure found by
+// Payload.StrictBodies. Use errors.As to retrieve it, or to retrieve the
+// first *ConversionError.
+type ConversionErrors []*ConversionError
+
+// Error implements the error interface.
+func (c ConversionErrors) Error() string { return joinErrors(c) }
+
+// Unwrap returns the individual errors.
+func (c ConversionErrors) Unwrap() []error { return unwrapErrors(c) }
+
+// StrictBodies is like Bodies but reports every non-null value that could
+// not be converted to its field's type as a ConversionErrors, instead of
+// storing nil indistinguishably from a missing value. The converted bodies
+// are returned alongside a ConversionErrors so callers may inspect them;
+// any other error returns nil bodies.
+func (p *Payload) StrictBodies() ([]Body, error) {
+	records, err := p.Records()
+	if err != nil {
+		return nil, err
+	}
+	var errs ConversionErrors
+	bodies := make([]Body, len(records))
+	for i, r := range records {
+		bodies[i] = r.body()
+		errs = append(errs, r.conversionErrors(i)...)
+	}
+	if len(errs) > 0 {
+		return bodies, errs
+	}
+	return bodies, nil
+}
+
+// StrictBody is like Body but also reports conversion failures in the
+// current row as a ConversionErrors.
+func (s *Stream) StrictBody() (Body, error) {
+	b := s.rec.body()
+	if errs := s.rec.conversionErrors(s.row - 1); len(errs) > 0 {
+		return b, errs
+	}
+	return b, nil
+}
+
+// conversionErrors converts every registered field in r with the same
+// rules as body and reports the non-null values that failed. Fields that
+// are not in the registry are not decoded into Body and are ignored.
+func (r Record) conversionErrors(row int) ConversionErrors {
+	var errs ConversionErrors
+	for _, info := range fieldInfos {
+		v, ok := r[info.Field]
+		if !ok || v == nil {
+			continue
+		}
+		var failed bool
+		switch info.Type {
+		case TypeFloat:
+			failed = r.getFloat(info.Field) == nil
+		case TypeInt:
+			failed = r.getInt(info.Field) == nil
+		case TypeBool:
+			failed = r.getBool(info.Field) == nil
+		}
+		if failed {
+			errs = append(errs, &ConversionError{Row: row, Field: info.Field, Value: v, Type: info.Type})
+		}
+	}
+	return errs
+}
@@ -0,0 +1,107 @@
+package sbdb
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestPayload_StrictBodies(t *testing.T) {
+	tests := []struct {
+		name     string
+		payload  string
+		want     []Body
+		wantErrs ConversionErrors
+		wantErr  bool
+	}{
+		{
+			name:    "valid",
+			payload: `{"fields":["spkid","neo","e","name"],"data":[["20000433","Y","0.22","Eros"],[null,null,null,null]],"count":2}`,
+			want: []Body{
+				{Identity: Identity{SpkID: ptrTo(20000433), NEO: ptrTo(true), Name: ptrTo("Eros")}, Orbit: Orbit{Eccentricity: ptrTo(0.22)}},
+				{},
+			},
+		},
+		{
+			name:    "conversion failures",
+			payload: `{"fields":["spkid","neo","e","name"],"data":[["x","maybe","0.22","Eros"],["1","N","abc","Ceres"]],"count":2}`,
+			want: []Body{
+				{Identity: Identity{Name: ptrTo("Eros")}, Orbit: Orbit{Eccentricity: ptrTo(0.22)}},
+				{Identity: Identity{SpkID: ptrTo(1), NEO: ptrTo(false), Name: ptrTo("Ceres")}},
+			},
+			wantErrs: ConversionErrors{
+				{Row: 0, Field: SpkID, Value: "x", Type: TypeInt},
+				{Row: 0, Field: NEO, Value: "maybe", Type: TypeBool},
+				{Row: 1, Field: Eccentricity, Value: "abc", Type: TypeFloat},
+			},
+			wantErr: true,
+		},
+		{
+			name:    "unknown fields ignored",
+			payload: `{"fields":["spkid","extra"],"data":[[1,"x"]],"count":1}`,
+			want:    []Body{{Identity: Identity{SpkID: ptrTo(1)}}},
+		},
+		{
+			name:    "row length mismatch",
+			payload: `{"fields":["spkid","neo"],"data":[["1"]],"count":1}`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := Decode(bytes.NewBufferString(tt.payload))
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := p.StrictBodies()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("StrictBodies() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("StrictBodies() mismatch (-want +got):\n%s", diff)
+			}
+			var errs ConversionErrors
+			errors.As(err, &errs)
+			if diff := cmp.Diff(tt.wantErrs, errs); diff != "" {
+				t.Errorf("StrictBodies() errors mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestConversionErrors_As(t *testing.T) {
+	var err error = ConversionErrors{{Row: 2, Field: H, Value: json.Number("1e999"), Type: TypeFloat}}
+	var ce *ConversionError
+	if !errors.As(err, &ce) {
+		t.Fatal("errors.As(*ConversionError) = false")
+	}
+	if ce.Row != 2 || ce.Field != H {
+		t.Errorf("errors.As() = %+v", ce)
+	}
+	if !strings.Contains(err.Error(), "data element 2: cannot convert H value 1e999") {
+		t.Errorf("Error() = %q", err.Error())
+	}
+}
+
+func TestStream_StrictBody(t *testing.T) {
+	s := NewStream(strings.NewReader(`{"fields":["spkid"],"data":[["1"],["bad"]],"count":2}`))
+	var got []error
+	for s.Next() {
+		_, err := s.StrictBody()
+		got = append(got, err)
+	}
+	if err := s.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 || got[0] != nil || got[1] == nil {
+		t.Fatalf("StrictBody() errors = %v", got)
+	}
+	var ce *ConversionError
+	if !errors.As(got[1], &ce) || ce.Row != 1 || ce.Field != SpkID {
+		t.Errorf("StrictBody() error = %v", got[1])
+	}
+}
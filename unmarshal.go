@@ -0,0 +1,306 @@
+package sbdb
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Unmarshal decodes every row of p into a new value of the struct type T.
+// Struct fields are mapped to SBDB fields with `sbdb:"name"` tags:
+//
+//	type Asteroid struct {
+//		ID    int       `sbdb:"spkid"`
+//		Name  *string   `sbdb:"full_name"`
+//		H     *float64  `sbdb:"H"`
+//		First time.Time `sbdb:"first_obs"`
+//		Orbit struct {
+//			E float64 `sbdb:"e"`
+//		}
+//	}
+//
+// Tagged fields may be strings, bools, integers, floats or time.Time, or
+// pointers to them. Untagged struct fields, including embedded ones, are
+// walked recursively; other untagged fields and fields tagged "-" are
+// ignored. Values are converted with the same rules as Payload.Bodies.
+// Null and missing values leave the field at its zero value, so use
+// pointers to tell them apart from zero.
+//
+// Times are parsed from ISO dates ("2006-01-02", optionally with a time of
+// day or a fractional day such as "2006-01-02.5") or, for numeric values,
+// from Julian dates.
+//
+// As with Payload.StrictBodies, values that fail to convert are reported
+// as a ConversionErrors returned alongside the decoded rows. A value that
+// fails to parse as a time is reported with its field's registered type,
+// or TypeString for fields not in the registry.
+func Unmarshal[T any](p *Payload) ([]T, error) {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	plan, err := planFor(t)
+	if err != nil {
+		return nil, err
+	}
+	records, err := p.Records()
+	if err != nil {
+		return nil, err
+	}
+	out := make([]T, len(records))
+	var errs ConversionErrors
+	for i, r := range records {
+		errs = append(errs, plan.apply(r, i, reflect.ValueOf(&out[i]).Elem())...)
+	}
+	if len(errs) > 0 {
+		return out, errs
+	}
+	return out, nil
+}
+
+// UnmarshalRecord stores r in the struct pointed to by v, following the
+// rules of Unmarshal. Conversion failures are reported with Row 0.
+func UnmarshalRecord(r Record, v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return fmt.Errorf("UnmarshalRecord(non-nil pointer required, got %T)", v)
+	}
+	plan, err := planFor(rv.Type().Elem())
+	if err != nil {
+		return err
+	}
+	if errs := plan.apply(r, 0, rv.Elem()); len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// structPlan lists the tagged fields of a struct type.
+type structPlan []fieldPlan
+
+// fieldPlan maps one SBDB field onto a struct field.
+type fieldPlan struct {
+	field Field
+	index []int
+	typ   reflect.Type
+	kind  planKind
+}
+
+// planKind is how a struct field's value is converted.
+type planKind uint
+
+const (
+	kindString planKind = iota + 1
+	kindBool
+	kindInt
+	kindUint
+	kindFloat
+	kindTime
+)
+
+var (
+	timeType  = reflect.TypeOf(time.Time{})
+	planCache sync.Map // map[reflect.Type]structPlan
+)
+
+func planFor(t reflect.Type) (structPlan, error) {
+	if p, ok := planCache.Load(t); ok {
+		return p.(structPlan), nil
+	}
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("cannot unmarshal into %s: not a struct", t)
+	}
+	var p structPlan
+	if err := p.build(t, nil); err != nil {
+		return nil, err
+	}
+	planCache.Store(t, p)
+	return p, nil
+}
+
+func (p *structPlan) build(t reflect.Type, index []int) error {
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		tag, tagged := sf.Tag.Lookup("sbdb")
+		// Exported fields of embedded unexported structs are still settable.
+		if tag == "-" || !sf.IsExported() && !(sf.Anonymous && sf.Type.Kind() == reflect.Struct) {
+			continue
+		}
+		idx := append(append([]int(nil), index...), i)
+		if !tagged {
+			if sf.Type.Kind() == reflect.Struct && sf.Type != timeType {
+				if err := p.build(sf.Type, idx); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		kind := planKindOf(sf.Type)
+		if kind == 0 {
+			return fmt.Errorf("cannot unmarshal %s into field %s of type %s", tag, sf.Name, sf.Type)
+		}
+		*p = append(*p, fieldPlan{field: Field(tag), index: idx, typ: sf.Type, kind: kind})
+	}
+	return nil
+}
+
+func (p structPlan) apply(r Record, row int, dst reflect.Value) ConversionErrors {
+	var errs ConversionErrors
+	for _, fp := range p {
+		raw := r[fp.field]
+		if raw == nil {
+			continue
+		}
+		t := fp.typ
+		if t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+		v, ok := r.convert(fp.field, t, fp.kind)
+		if !ok {
+			errs = append(errs, &ConversionError{Row: row, Field: fp.field, Value: raw, Type: fp.kind.valueType(fp.field)})
+			continue
+		}
+		f := dst.FieldByIndex(fp.index)
+		if fp.typ.Kind() == reflect.Pointer {
+			ptr := reflect.New(t)
+			ptr.Elem().Set(v)
+			v = ptr
+		}
+		f.Set(v)
+	}
+	return errs
+}
+
+// planKindOf returns how values of a Go type are converted, or 0 if the
+// type is not supported by Unmarshal.
+func planKindOf(t reflect.Type) planKind {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == timeType {
+		return kindTime
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return kindString
+	case reflect.Bool:
+		return kindBool
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return kindInt
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return kindUint
+	case reflect.Float32, reflect.Float64:
+		return kindFloat
+	}
+	return 0
+}
+
+// valueType returns the ValueType reported in a ConversionError for a
+// value of field that failed to convert as k.
+func (k planKind) valueType(field Field) ValueType {
+	switch k {
+	case kindBool:
+		return TypeBool
+	case kindInt, kindUint:
+		return TypeInt
+	case kindFloat:
+		return TypeFloat
+	case kindTime:
+		if info, ok := field.Info(); ok {
+			return info.Type
+		}
+	}
+	return TypeString
+}
+
+// convert returns the value of field converted to t as k, reporting false
+// if the value cannot be represented.
+func (r Record) convert(field Field, t reflect.Type, k planKind) (reflect.Value, bool) {
+	v := reflect.New(t).Elem()
+	switch k {
+	case kindTime:
+		tm, err := parseTime(r[field])
+		if err != nil {
+			logFailedTypeAssert("convert(time.Time)", field, r[field])
+			return v, false
+		}
+		v.Set(reflect.ValueOf(tm))
+	case kindString:
+		s := r.getString(field)
+		v.SetString(*s)
+	case kindBool:
+		b := r.getBool(field)
+		if b == nil {
+			return v, false
+		}
+		v.SetBool(*b)
+	case kindInt:
+		i := r.getInt(field)
+		if i == nil || v.OverflowInt(int64(*i)) {
+			return v, false
+		}
+		v.SetInt(int64(*i))
+	case kindUint:
+		i := r.getInt(field)
+		if i == nil || *i < 0 || v.OverflowUint(uint64(*i)) {
+			return v, false
+		}
+		v.SetUint(uint64(*i))
+	case kindFloat:
+		f := r.getFloat(field)
+		if f == nil || v.OverflowFloat(*f) {
+			return v, false
+		}
+		v.SetFloat(*f)
+	}
+	return v, true
+}
+
+// julianUnixEpoch is the Julian date of 1970-01-01T00:00:00Z.
+const julianUnixEpoch = 2440587.5
+
+var timeLayouts = []string{
+	time.RFC3339,
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-Jan-02 15:04:05",
+	"2006-Jan-02 15:04",
+	"2006-01-02",
+	"2006-Jan-02",
+}
+
+// parseTime converts an SBDB date value to a UTC time. Numbers are Julian
+// dates; strings are calendar dates, which may carry a fractional day
+// ("2006-01-02.25" or "20060102.25"), or Julian dates.
+func parseTime(v any) (time.Time, error) {
+	if s, ok := v.(string); ok {
+		for _, layout := range timeLayouts {
+			if t, err := time.Parse(layout, s); err == nil {
+				return t.UTC(), nil
+			}
+		}
+		date, frac, _ := strings.Cut(s, ".")
+		for _, layout := range []string{"2006-01-02", "20060102"} {
+			t, err := time.Parse(layout, date)
+			if err != nil {
+				continue
+			}
+			if strings.Trim(frac, "0123456789") != "" {
+				return time.Time{}, fmt.Errorf("invalid date %q", s)
+			}
+			if frac != "" {
+				day, _ := strconv.ParseFloat("0."+frac, 64)
+				t = t.Add(time.Duration(day * float64(24*time.Hour)))
+			}
+			return t, nil
+		}
+	}
+	jd := Record{"": v}.getFloat("")
+	if jd == nil {
+		return time.Time{}, fmt.Errorf("invalid date %v", v)
+	}
+	sec := (*jd - julianUnixEpoch) * 86400
+	whole := math.Floor(sec)
+	return time.Unix(int64(whole), int64((sec-whole)*1e9)).UTC(), nil
+}
@@ -0,0 +1,145 @@
+package sbdb
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+type AsteroidElements struct {
+	E   float64  `sbdb:"e"`
+	Q   *float32 `sbdb:"q"`
+	Arc uint16   `sbdb:"data_arc"`
+}
+
+type AsteroidIdentity struct {
+	ID   int     `sbdb:"spkid"`
+	Name *string `sbdb:"full_name"`
+}
+
+type Asteroid struct {
+	AsteroidIdentity
+	NEO      bool       `sbdb:"neo"`
+	PHA      *bool      `sbdb:"pha"`
+	Epoch    time.Time  `sbdb:"epoch"`
+	FirstObs *time.Time `sbdb:"first_obs"`
+	Elements AsteroidElements
+	Ignored  string `sbdb:"-"`
+	Note     string
+}
+
+func TestUnmarshal(t *testing.T) {
+	p, err := Decode(bytes.NewBufferString(`{"fields":["spkid","full_name","neo","pha","epoch","first_obs","e","q","data_arc"],"data":[
+		["2000433","   433 Eros (A898 PA)","Y","N","2459600.5","1893-10-29","0.2229","1.133","46455"],
+		["2000001",null,"N",null,null,null,"0.0785",null,null]
+	],"count":2}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := Unmarshal[Asteroid](p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Asteroid{
+		{
+			AsteroidIdentity: AsteroidIdentity{ID: 2000433, Name: ptrTo("433 Eros (A898 PA)")},
+			NEO:              true,
+			PHA:              ptrTo(false),
+			Epoch:            time.Date(2022, 1, 21, 0, 0, 0, 0, time.UTC),
+			FirstObs:         ptrTo(time.Date(1893, 10, 29, 0, 0, 0, 0, time.UTC)),
+			Elements:         AsteroidElements{E: 0.2229, Q: ptrTo(float32(1.133)), Arc: 46455},
+		},
+		{
+			AsteroidIdentity: AsteroidIdentity{ID: 2000001},
+			Elements:         AsteroidElements{E: 0.0785},
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unmarshal() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestUnmarshal_ConversionErrors(t *testing.T) {
+	p, err := Decode(bytes.NewBufferString(`{"fields":["spkid","neo","e","data_arc","epoch"],"data":[
+		["x","maybe","0.1","-1","soon"]
+	],"count":1}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := Unmarshal[Asteroid](p)
+	var errs ConversionErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("Unmarshal() error = %v, want ConversionErrors", err)
+	}
+	want := ConversionErrors{
+		{Row: 0, Field: SpkID, Value: "x", Type: TypeInt},
+		{Row: 0, Field: NEO, Value: "maybe", Type: TypeBool},
+		{Row: 0, Field: Epoch, Value: "soon", Type: TypeFloat},
+		{Row: 0, Field: DataArc, Value: "-1", Type: TypeInt},
+	}
+	if diff := cmp.Diff(want, errs); diff != "" {
+		t.Errorf("Unmarshal() errors mismatch (-want +got):\n%s", diff)
+	}
+	if len(got) != 1 || got[0].Elements.E != 0.1 {
+		t.Errorf("Unmarshal() = %+v, want converted values alongside errors", got)
+	}
+}
+
+func TestUnmarshal_InvalidType(t *testing.T) {
+	p := &Payload{}
+	if _, err := Unmarshal[int](p); err == nil {
+		t.Error("Unmarshal[int]() error = nil, want error")
+	}
+	type bad struct {
+		Values []string `sbdb:"name"`
+	}
+	if _, err := Unmarshal[bad](p); err == nil {
+		t.Error("Unmarshal[bad]() error = nil, want error")
+	}
+}
+
+func TestUnmarshalRecord(t *testing.T) {
+	var got AsteroidIdentity
+	if err := UnmarshalRecord(Record{SpkID: 42, FullName: "Test"}, &got); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(AsteroidIdentity{ID: 42, Name: ptrTo("Test")}, got); diff != "" {
+		t.Errorf("UnmarshalRecord() mismatch (-want +got):\n%s", diff)
+	}
+	if err := UnmarshalRecord(Record{}, got); err == nil {
+		t.Error("UnmarshalRecord(non-pointer) error = nil, want error")
+	}
+}
+
+func TestParseTime(t *testing.T) {
+	tests := []struct {
+		name    string
+		v       any
+		want    time.Time
+		wantErr bool
+	}{
+		{"date", "2017-01-01", time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC), false},
+		{"date time", "2017-01-01 12:30", time.Date(2017, 1, 1, 12, 30, 0, 0, time.UTC), false},
+		{"month name", "2017-Jan-01 06:00", time.Date(2017, 1, 1, 6, 0, 0, 0, time.UTC), false},
+		{"fractional day", "2017-01-01.25", time.Date(2017, 1, 1, 6, 0, 0, 0, time.UTC), false},
+		{"compact fractional day", "20170101.5", time.Date(2017, 1, 1, 12, 0, 0, 0, time.UTC), false},
+		{"julian date string", "2459600.5", time.Date(2022, 1, 21, 0, 0, 0, 0, time.UTC), false},
+		{"julian date number", 2440588.0, time.Date(1970, 1, 1, 12, 0, 0, 0, time.UTC), false},
+		{"invalid", "soon", time.Time{}, true},
+		{"invalid fraction", "2017-01-01.x", time.Time{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseTime(tt.v)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseTime() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("parseTime() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
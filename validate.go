@@ -0,0 +1,192 @@
+package sbdb
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ValidationError describes a single problem found by Filter.Validate.
+type ValidationError struct {
+	Param string         // Query parameter the problem relates to, e.g. "fields" or "sb-cf"
+	Field Field          // Field involved, if any
+	Expr  ComparisonExpr // Offending comparison, if any
+	Msg   string         // Description of the problem
+}
+
+// Error implements the error interface.
+func (e *ValidationError) Error() string {
+	if e.Expr != "" {
+		return fmt.Sprintf("%s: %q: %s", e.Param, string(e.Expr), e.Msg)
+	}
+	return fmt.Sprintf("%s: %s", e.Param, e.Msg)
+}
+
+// ValidationErrors lists every problem found by Filter.Validate. Use
+// errors.As to retrieve it, or to retrieve the first *ValidationError.
+type ValidationErrors []*ValidationError
+
+// Error implements the error interface.
+func (v ValidationErrors) Error() string { return joinErrors(v) }
+
+// Unwrap returns the individual errors.
+func (v ValidationErrors) Unwrap() []error { return unwrapErrors(v) }
+
+// asteroidClasses are the orbit classes that only apply to asteroids; all
+// other valid classes only apply to comets.
+var asteroidClasses = map[ClassFilter]bool{
+	IEO: true, ATE: true, APO: true, AMO: true, MCA: true, IMB: true, MBA: true,
+	OMB: true, TJN: true, AST: true, CEN: true, TNO: true, PAA: true, HYA: true,
+}
+
+// Validate checks f against the field registry and the rules of the SBDB
+// Query API without sending a request. Unlike Values, which stops at the
+// first problem it cannot encode, Validate reports every problem it finds
+// as a ValidationErrors. It returns nil if f is valid.
+func (f Filter) Validate() error {
+	var errs ValidationErrors
+	var add reportFunc = func(param string, field Field, expr ComparisonExpr, format string, args ...any) {
+		errs = append(errs, &ValidationError{Param: param, Field: field, Expr: expr, Msg: fmt.Sprintf(format, args...)})
+	}
+
+	if len(f.Fields) == 0 {
+		add("fields", "", "", "must provide at least one field")
+	}
+	for _, name := range f.Fields.List() {
+		if _, ok := Field(name).Info(); !ok {
+			add("fields", Field(name), "", "unknown field %q", name)
+		}
+	}
+
+	if f.NumberedStatus > NumStatusUnnumbered {
+		add("sb-ns", "", "", "%v", f.NumberedStatus)
+	}
+	if f.Kind > KindComet {
+		add("sb-kind", "", "", "%v", f.Kind)
+	}
+	if f.Group > GroupPHA {
+		add("sb-group", "", "", "%v", f.Group)
+	}
+
+	if len(f.Classes) > 3 {
+		add("sb-class", "", "", "len(ClassFilters) = %d, max = 3", len(f.Classes))
+	}
+	for _, c := range f.Classes {
+		switch _, ok := classCodes[c]; {
+		case !ok:
+			add("sb-class", "", "", "%v", c)
+		case f.Kind == KindComet && asteroidClasses[c]:
+			add("sb-class", "", "", "asteroid class %v cannot match KindComet", c)
+		case f.Kind == KindAsteroid && !asteroidClasses[c]:
+			add("sb-class", "", "", "comet class %v cannot match KindAsteroid", c)
+		}
+	}
+
+	if len(f.Sort) > 3 {
+		add("sort", "", "", "len(SortKeys) = %d, max = 3", len(f.Sort))
+	}
+	for _, k := range f.Sort {
+		if _, ok := k.Field.Info(); !ok {
+			add("sort", k.Field, "", "unknown field %q", k.Field)
+		}
+	}
+
+	if f.FieldConstraints != nil {
+		validateExpr(f.FieldConstraints, add)
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+// reportFunc records a validation problem.
+type reportFunc func(param string, field Field, expr ComparisonExpr, format string, args ...any)
+
+func validateExpr(e Expr, add reportFunc) {
+	const param = "sb-cf"
+	switch e := e.(type) {
+	case And:
+		if len(e) == 0 {
+			add(param, "", "", "empty AND group")
+		}
+		for _, sub := range e {
+			validateExpr(sub, add)
+		}
+	case Or:
+		if len(e) == 0 {
+			add(param, "", "", "empty OR group")
+		}
+		for _, sub := range e {
+			validateExpr(sub, add)
+		}
+	case ComparisonExpr:
+		validateComparison(e, add)
+	default:
+		add(param, "", "", "unsupported expression type %T", e)
+	}
+}
+
+func validateComparison(c ComparisonExpr, add reportFunc) {
+	const param = "sb-cf"
+	cmp, err := c.parse()
+	if err != nil {
+		add(param, "", c, "%v", err)
+		return
+	}
+	info, ok := cmp.field.Info()
+	if !ok {
+		add(param, cmp.field, c, "unknown field %q", cmp.field)
+		return
+	}
+	if !info.Constraint {
+		add(param, cmp.field, c, "field %q cannot be used in constraints", cmp.field)
+	}
+
+	numeric := info.Type == TypeFloat || info.Type == TypeInt
+	isNumber := func(s string) bool {
+		_, err := strconv.ParseFloat(s, 64)
+		return err == nil
+	}
+	switch cmp.op {
+	case OpDF, OpND:
+	case OpRE:
+		if numeric || info.Type == TypeBool {
+			add(param, cmp.field, c, "RE is not supported on %s field %q", info.Type, cmp.field)
+		} else if _, err := compileRegexp(cmp.args[0]); err != nil {
+			add(param, cmp.field, c, "%v", err)
+		}
+	case OpRG:
+		if !numeric {
+			add(param, cmp.field, c, "RG is not supported on %s field %q", info.Type, cmp.field)
+		}
+		lo, errLo := strconv.ParseFloat(cmp.args[0], 64)
+		hi, errHi := strconv.ParseFloat(cmp.args[1], 64)
+		switch {
+		case errLo != nil || errHi != nil:
+			add(param, cmp.field, c, "RG bounds must be numeric")
+		case lo > hi:
+			add(param, cmp.field, c, "RG minimum %v is greater than maximum %v", lo, hi)
+		}
+	default:
+		v := cmp.args[0]
+		switch {
+		case numeric && !isNumber(v):
+			add(param, cmp.field, c, "%v requires a numeric value for %s field %q", cmp.op, info.Type, cmp.field)
+		case info.Type == TypeBool && cmp.op != OpEQ && cmp.op != OpNE:
+			add(param, cmp.field, c, "%v is not supported on bool field %q", cmp.op, cmp.field)
+		case info.Type == TypeBool && !isFlag(v):
+			add(param, cmp.field, c, "bool field %q must be compared with Y or N", cmp.field)
+		}
+	}
+}
+
+// isFlag reports whether v is a boolean flag the API understands.
+func isFlag(v string) bool {
+	switch strings.ToUpper(v) {
+	case "Y", "N", "T", "F":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,128 @@
+package sbdb
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestFilter_Validate(t *testing.T) {
+	fields := NewFieldSet(SpkID, FullName)
+	tests := []struct {
+		name   string
+		filter Filter
+		want   []ValidationError
+	}{
+		{
+			name:   "valid",
+			filter: Filter{Fields: fields, Kind: KindAsteroid, Classes: ClassFilters{APO, ATE}, FieldConstraints: And{GT("e", "0.9"), RG("q", "0", "1.3"), RE("class", "^A"), EQ("neo", "Y"), DF("albedo")}, Sort: SortKeys{Asc(MOID), Desc(H)}},
+		},
+		{
+			name:   "no fields",
+			filter: Filter{},
+			want:   []ValidationError{{Param: "fields", Msg: "must provide at least one field"}},
+		},
+		{
+			name:   "unknown field",
+			filter: Filter{Fields: NewFieldSet(SpkID, "bogus")},
+			want:   []ValidationError{{Param: "fields", Field: "bogus", Msg: `unknown field "bogus"`}},
+		},
+		{
+			name:   "invalid enums",
+			filter: Filter{Fields: fields, NumberedStatus: 9, Kind: 9, Group: 9},
+			want: []ValidationError{
+				{Param: "sb-ns", Msg: "Invalid NumStatusFilter(9)"},
+				{Param: "sb-kind", Msg: "Invalid KindFilter(9)"},
+				{Param: "sb-group", Msg: "Invalid GroupFilter(9)"},
+			},
+		},
+		{
+			name:   "classes",
+			filter: Filter{Fields: fields, Kind: KindComet, Classes: ClassFilters{APO, JFc, 99, HTC}},
+			want: []ValidationError{
+				{Param: "sb-class", Msg: "len(ClassFilters) = 4, max = 3"},
+				{Param: "sb-class", Msg: "asteroid class APO cannot match KindComet"},
+				{Param: "sb-class", Msg: "Invalid ClassFilter(99)"},
+			},
+		},
+		{
+			name:   "sort",
+			filter: Filter{Fields: fields, Sort: SortKeys{Asc(MOID), Desc("bogus"), Asc(H), Asc(Albedo)}},
+			want: []ValidationError{
+				{Param: "sort", Msg: "len(SortKeys) = 4, max = 3"},
+				{Param: "sort", Field: "bogus", Msg: `unknown field "bogus"`},
+			},
+		},
+		{
+			name:   "comet class with asteroids",
+			filter: Filter{Fields: fields, Kind: KindAsteroid, Classes: ClassFilters{JFc}},
+			want:   []ValidationError{{Param: "sb-class", Msg: "comet class JFc cannot match KindAsteroid"}},
+		},
+		{
+			name: "constraints",
+			filter: Filter{Fields: fields, FieldConstraints: Or{
+				EQ("bogus", "1"),
+				RG("q", "a", "1"),
+				RG("e", "2", "1"),
+				RE("e", "^0"),
+				LT("H", "bright"),
+				EQ("full_name", "Ceres"),
+				GT("neo", "Y"),
+				EQ("pha", "yes"),
+				RG("class", "1", "2"),
+				RE("name", "("),
+				ComparisonExpr("e|XX|1"),
+				And{},
+			}},
+			want: []ValidationError{
+				{Param: "sb-cf", Field: "bogus", Expr: "bogus|EQ|1", Msg: `unknown field "bogus"`},
+				{Param: "sb-cf", Field: "q", Expr: "q|RG|a|1", Msg: "RG bounds must be numeric"},
+				{Param: "sb-cf", Field: "e", Expr: "e|RG|2|1", Msg: "RG minimum 2 is greater than maximum 1"},
+				{Param: "sb-cf", Field: "e", Expr: "e|RE|^0", Msg: `RE is not supported on float field "e"`},
+				{Param: "sb-cf", Field: "H", Expr: "H|LT|bright", Msg: `LT requires a numeric value for float field "H"`},
+				{Param: "sb-cf", Field: "full_name", Expr: "full_name|EQ|Ceres", Msg: `field "full_name" cannot be used in constraints`},
+				{Param: "sb-cf", Field: "neo", Expr: "neo|GT|Y", Msg: `GT is not supported on bool field "neo"`},
+				{Param: "sb-cf", Field: "pha", Expr: "pha|EQ|yes", Msg: `bool field "pha" must be compared with Y or N`},
+				{Param: "sb-cf", Field: "class", Expr: "class|RG|1|2", Msg: `RG is not supported on string field "class"`},
+				{Param: "sb-cf", Field: "name", Expr: "name|RE|(", Msg: "invalid regular expression \"(\": error parsing regexp: missing closing ): `(`"},
+				{Param: "sb-cf", Expr: "e|XX|1", Msg: `invalid expression "e|XX|1": unknown operator "XX"`},
+				{Param: "sb-cf", Msg: "empty AND group"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.filter.Validate()
+			if tt.want == nil {
+				if err != nil {
+					t.Fatalf("Validate() error = %v, want nil", err)
+				}
+				return
+			}
+			var got ValidationErrors
+			if !errors.As(err, &got) {
+				t.Fatalf("Validate() error = %v, want ValidationErrors", err)
+			}
+			var gotValues []ValidationError
+			for _, e := range got {
+				gotValues = append(gotValues, *e)
+			}
+			if diff := cmp.Diff(tt.want, gotValues); diff != "" {
+				t.Errorf("Validate() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestValidationErrors(t *testing.T) {
+	err := Filter{Fields: NewFieldSet("bogus"), Classes: ClassFilters{99}}.Validate()
+	want := `fields: unknown field "bogus"; sb-class: Invalid ClassFilter(99)`
+	if err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+	var first *ValidationError
+	if !errors.As(err, &first) || first.Field != "bogus" {
+		t.Errorf("errors.As(*ValidationError) = %v", first)
+	}
+}